@@ -0,0 +1,45 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// swiftCode is a bank code, country code and location code, optionally followed by a branch code
+var swiftCode = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// aba strips a US bank routing number to its 9 digits, checking its check digit
+func aba(s string) (string, error) {
+	d := onlyNumbers(s)
+	if len(d) == 9 && strings.Trim(s, "0123456789- .") == "" {
+		sum := 0
+		for i, w := range []int{3, 7, 1, 3, 7, 1, 3, 7, 1} {
+			sum += w * int(d[i]-'0')
+		}
+		if sum%10 == 0 {
+			return d, nil
+		}
+	}
+	return s, fmt.Errorf("%q is not a routing number", s)
+}
+
+// sortCode strips a UK sort code to its 6 digits
+func sortCode(s string) (string, error) {
+	d := onlyNumbers(s)
+	if len(d) != 6 || strings.Trim(s, "0123456789- ") != "" {
+		return s, fmt.Errorf("%q is not a sort code", s)
+	}
+	return d, nil
+}
+
+// swift uppercases a SWIFT or BIC code and removes spaces, checking it's 8 or 11 characters
+func swift(s string) (string, error) {
+	c := strings.Join(strings.Fields(strings.ToUpper(s)), "")
+	if !swiftCode.MatchString(c) {
+		return s, fmt.Errorf("%q is not a SWIFT code", s)
+	}
+	return c, nil
+}
@@ -0,0 +1,52 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestABA() {
+	assert := assert.New(t.T())
+
+	for _, in := range []string{"011000015", "0110-0001-5", " 011 000 015 "} {
+		out, err := aba(in)
+		assert.NoError(err, in)
+		assert.Equal("011000015", out, in)
+	}
+	for _, in := range []string{"011000016", "01100001", "O11000015", ""} {
+		out, err := aba(in)
+		assert.Error(err, in)
+		assert.Equal(in, out)
+	}
+}
+
+func (t *testSuite) TestSortCode() {
+	assert := assert.New(t.T())
+
+	for _, in := range []string{"12-34-56", "123456", "12 34 56"} {
+		out, err := sortCode(in)
+		assert.NoError(err, in)
+		assert.Equal("123456", out, in)
+	}
+	_, err := sortCode("12-34-5")
+	assert.EqualError(err, `"12-34-5" is not a sort code`)
+}
+
+func (t *testSuite) TestSwift() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"deutdeff":        "DEUTDEFF",
+		" DEUT DE FF 500": "DEUTDEFF500",
+		"nwbkgb2l":        "NWBKGB2L",
+	} {
+		out, err := swift(in)
+		assert.NoError(err, in)
+		assert.Equal(want, out, in)
+	}
+	for _, in := range []string{"DEUTDEF", "DEUTDEFF50", "1EUTDEFF", "DEUT-DEFF"} {
+		_, err := swift(in)
+		assert.Error(err, in)
+	}
+}
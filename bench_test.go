@@ -0,0 +1,100 @@
+package conform
+
+import (
+	"strconv"
+	"testing"
+)
+
+type benchItem struct {
+	SKU  string `conform:"trim,upper"`
+	Name string `conform:"trim,squeeze"`
+}
+
+type benchOrder struct {
+	Customer string               `conform:"trim,name"`
+	Items    []benchItem          `conform:""`
+	ByID     map[string]benchItem `conform:""`
+	Tags     []string             `conform:"trim,lower"`
+}
+
+func newBenchOrder() benchOrder {
+	o := benchOrder{Customer: "  jane   SMITH ", ByID: map[string]benchItem{}}
+	for i := 0; i < 50; i++ {
+		item := benchItem{SKU: " ab-" + strconv.Itoa(i) + " ", Name: "  Wiiiidget  !!!  "}
+		o.Items = append(o.Items, item)
+		o.ByID[strconv.Itoa(i)] = item
+		o.Tags = append(o.Tags, " Tag"+strconv.Itoa(i))
+	}
+	return o
+}
+
+func BenchmarkStrings(b *testing.B) {
+	b.ReportAllocs()
+	orders := make([]benchOrder, b.N)
+	for i := range orders {
+		orders[i] = newBenchOrder()
+	}
+	b.ResetTimer()
+	for i := range orders {
+		if err := Strings(&orders[i]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStringsUnchanged(b *testing.B) {
+	b.ReportAllocs()
+	o := newBenchOrder()
+	if err := Strings(&o); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Strings(&o); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTags(b *testing.B) {
+	for _, tags := range []string{"trim,lower", "squeeze", "slug", "wrap=20", "excerpt=20"} {
+		p, err := Compile(tags)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(tags, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				p.Apply("  The Quick   BROWN fox jumps over the laaaazy dog, again and again  ")
+			}
+		})
+	}
+}
+
+func BenchmarkMaps(b *testing.B) {
+	strs := map[string]string{}
+	ptrs := map[string]*benchItem{}
+	items := map[string]benchItem{}
+	for i := 0; i < 50; i++ {
+		key := strconv.Itoa(i)
+		strs[key] = " Tag" + key
+		ptrs[key] = &benchItem{SKU: " ab-" + key + " ", Name: "widget"}
+		items[key] = benchItem{SKU: " ab-" + key + " ", Name: "widget"}
+	}
+	for name, v := range map[string]interface{}{
+		"strings": &struct {
+			M map[string]string `conform:"trim,lower"`
+		}{strs},
+		"pointers": &struct{ M map[string]*benchItem }{ptrs},
+		"structs":  &struct{ M map[string]benchItem }{items},
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := Strings(v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package conform
+
+import "strings"
+
+// isBidiControl reports whether r is an embedding, override or isolate control, which can make text display in
+// a different order to the one it's stored in
+func isBidiControl(r rune) bool {
+	return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// noBidi removes bidirectional embedding, override and isolate controls
+func noBidi(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isBidiControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
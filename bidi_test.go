@@ -0,0 +1,24 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestNoBidi() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		File string `conform:"nobidi"`
+		Name string `conform:"name"`
+	}
+	s.File = "invoice\u202efdp.exe"
+	s.Name = "\u2067ann\u2069 \u202dlee\u202c"
+
+	assert.NoError(Strings(&s))
+	assert.Equal("invoicefdp.exe", s.File)
+	assert.Equal("Ann Lee", s.Name, "name should drop bidi controls too")
+
+	assert.Equal("שלום \u200fhello", noBidi("\u202bשלום\u202c \u200fhello"), "Marks don't override, so should be kept")
+}
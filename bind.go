@@ -0,0 +1,71 @@
+package conform
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// BindValues sets the string fields of the struct dst points to from values, such as a parsed form, then
+// conforms it. Fields are matched by their `form` tag, then their `json` tag, then their name. Fields that
+// aren't strings, pointers to strings or slices of strings are left alone.
+func BindValues(values url.Values, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: %T", ErrNotPointer, dst)
+	}
+	if v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a pointer to a struct", ErrUnsupportedKind, dst)
+	}
+	bindStruct(values, v.Elem())
+	return Strings(dst)
+}
+
+func bindStruct(values url.Values, s reflect.Value) {
+	t := s.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		el := s.Field(i)
+		if !el.CanSet() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			bindStruct(values, el)
+			continue
+		}
+
+		key := formKey(f)
+		vals, ok := values[key]
+		if key == "-" || !ok || len(vals) == 0 {
+			continue
+		}
+
+		switch {
+		case f.Type.Kind() == reflect.String:
+			el.SetString(vals[0])
+		case f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.String:
+			p := reflect.New(f.Type.Elem())
+			p.Elem().SetString(vals[0])
+			el.Set(p)
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String:
+			sl := reflect.MakeSlice(f.Type, len(vals), len(vals))
+			for j, val := range vals {
+				sl.Index(j).SetString(val)
+			}
+			el.Set(sl)
+		}
+	}
+}
+
+// formKey returns the key a field is bound from
+func formKey(f reflect.StructField) string {
+	for _, name := range []string{"form", "json"} {
+		if tag, ok := f.Tag.Lookup(name); ok {
+			if key := strings.Split(tag, ",")[0]; key != "" {
+				return key
+			}
+		}
+	}
+	return f.Name
+}
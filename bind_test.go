@@ -0,0 +1,53 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"net/url"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestBindValues() {
+	assert := assert.New(t.T())
+
+	type Audit struct {
+		Source string `form:"source" conform:"lower"`
+	}
+	var form struct {
+		Audit
+		Name     string   `form:"name" conform:"trim,name"`
+		Email    *string  `json:"email,omitempty" conform:"email"`
+		Tags     []string `form:"tag" conform:"dive,hashtags"`
+		Country  string   `conform:"country"`
+		Age      int      `form:"age"`
+		Password string   `form:"-"`
+		private  string
+	}
+
+	values := url.Values{
+		"name":     {" ann  o'brien "},
+		"email":    {" ANN@X.IO "},
+		"tag":      {"Go", "#go lang"},
+		"Country":  {"united kingdom"},
+		"age":      {"42"},
+		"Password": {"secret"},
+		"source":   {"WEB"},
+		"private":  {"x"},
+	}
+	assert.NoError(BindValues(values, &form))
+	assert.Equal("Ann O'Brien", form.Name)
+	if assert.NotNil(form.Email) {
+		assert.Equal("ANN@x.io", *form.Email)
+	}
+	assert.Equal([]string{"go", "golang"}, form.Tags)
+	assert.Equal("GB", form.Country)
+	assert.Equal(0, form.Age, "Non-string fields should be left alone")
+	assert.Empty(form.Password)
+	assert.Empty(form.private)
+	assert.Equal("web", form.Source)
+
+	assert.Error(BindValues(values, form))
+	s := "x"
+	assert.Error(BindValues(values, &s))
+}
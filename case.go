@@ -0,0 +1,40 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// localeDirectives replace the case directives when a locale is given, following its rules, such as the dotted
+// and dotless i in Turkish
+var localeDirectives = map[string]func(locale string) transform{
+	"lower": func(locale string) transform {
+		return caser(func() cases.Caser { return cases.Lower(language.Make(locale)) })
+	},
+	"upper": func(locale string) transform {
+		return caser(func() cases.Caser { return cases.Upper(language.Make(locale)) })
+	},
+	"title": func(locale string) transform {
+		// like strings.Title, leave the rest of each word as it is
+		return caser(func() cases.Caser { return cases.Title(language.Make(locale), cases.NoLower) })
+	},
+}
+
+// checkLocale returns an error if locale, from a "locale=" tag, isn't a language tag
+func checkLocale(locale string) error {
+	if _, err := language.Parse(locale); err != nil {
+		return fmt.Errorf("%q is not a language", locale)
+	}
+	return nil
+}
+
+// caser adapts a cases.Caser. They aren't safe for concurrent use, so each call gets a new one.
+func caser(c func() cases.Caser) transform {
+	return func(s string) (string, error) {
+		return c().String(s), nil
+	}
+}
@@ -0,0 +1,95 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestSentence() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"THIS IS GREAT. BUY NOW!! really? YES": "This is great. Buy now!! Really? Yes",
+		"  hello world":                        "  Hello world",
+		"version 1.2 is out. get it":           "Version 1.2 is out. Get it",
+		"\"quoted\" start. (and) another":      "\"Quoted\" start. (And) another",
+		"ÉCOLE. ÉTÉ":                           "École. Été",
+		"":                                     "",
+	} {
+		assert.Equal(want, sentence(in), in)
+	}
+}
+
+func (t *testSuite) TestHeadline() {
+	assert := assert.New(t.T())
+
+	for _, c := range []struct{ lang, in, want string }{
+		{"en", "the lord of the rings", "The Lord of the Rings"},
+		{"en", "war AND peace", "War and Peace"},
+		{"en", "what it's good for", "What It's Good For"},
+		{"en", "star wars: a new hope", "Star Wars: A New Hope"},
+		{"en-GB", "  a tale of  two cities ", "  A Tale of  Two Cities "},
+		{"en", "iPhone in the house", "IPhone in the House"},
+		{"fr", "le rouge et le noir", "Le Rouge et le Noir"},
+		{"es", "cien años de soledad", "Cien Años de Soledad"},
+		{"nl", "de avonden", "De Avonden"},
+		{"en", "", ""},
+	} {
+		assert.Equal(c.want, headline(c.in, c.lang), c.in)
+	}
+
+	RegisterSmallWords("en", "Than")
+	assert.Equal("Better than Ever", headline("better than ever", "en"))
+
+	// registering while titles are being cased is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterSmallWords("en", "upon")
+	}()
+	headline("once upon a time", "en")
+	wg.Wait()
+	assert.Equal("Once upon a Time", headline("once upon a time", "en"))
+
+	var s struct {
+		Headline string `conform:"trim,title=en"`
+		Blurb    string `conform:"title"`
+	}
+	s.Headline = " the art of war "
+	s.Blurb = "the art of war"
+	assert.NoError(StringsWithOptions(&s, Options{Locale: "tr"}))
+	assert.Equal("The Art of War", s.Headline, "A locale shouldn't replace title=en")
+	assert.Equal("The Art Of War", s.Blurb)
+
+	_, err := Compile("title=notalanguage!")
+	assert.EqualError(err, `title=notalanguage!: "notalanguage!" is not a language`)
+}
+
+func (t *testSuite) TestLocaleTag() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Turkish string `conform:"upper,locale=tr"`
+		English string `conform:"locale=en,upper"`
+		Default string `conform:"upper"`
+	}
+	s.Turkish = "istanbul"
+	s.English = "istanbul"
+	s.Default = "istanbul"
+	assert.NoError(StringsWithOptions(&s, Options{Locale: "tr"}))
+	assert.Equal("İSTANBUL", s.Turkish)
+	assert.Equal("ISTANBUL", s.English, "locale= should override Options.Locale")
+	assert.Equal("İSTANBUL", s.Default)
+
+	p, err := Compile("trim,lower,locale=tr")
+	assert.NoError(err)
+	out, _ := p.Apply(" ISPARTA ")
+	assert.Equal("ısparta", out)
+
+	_, err = Compile("lower,locale=notalanguage!")
+	assert.EqualError(err, `locale=notalanguage!: "notalanguage!" is not a language`)
+}
@@ -0,0 +1,59 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// charsets are the legacy encodings decode= understands
+var charsets = map[string]*charmap.Charmap{
+	"latin1":       charmap.ISO8859_1,
+	"iso-8859-1":   charmap.ISO8859_1,
+	"windows-1252": charmap.Windows1252,
+	"cp1252":       charmap.Windows1252,
+}
+
+// decodeCharset fixes text from a legacy encoding. Invalid UTF-8 is taken to be raw bytes in that encoding and
+// transcoded. Valid UTF-8 is checked for mojibake, UTF-8 that was mistakenly decoded as the legacy encoding, such as
+// "cafÃ©", and repaired when encoding it back gives valid UTF-8.
+func decodeCharset(s string, cm *charmap.Charmap) string {
+	if !utf8.ValidString(s) {
+		out, err := cm.NewDecoder().String(s)
+		if err != nil {
+			return s
+		}
+		return out
+	}
+
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		c, ok := cm.EncodeRune(r)
+		// windows-1252 leaves five bytes undefined, which mis-decoders commonly pass through as C1 controls
+		if !ok && r >= 0x80 && r <= 0x9f {
+			c, ok = byte(r), true
+		}
+		if !ok {
+			return s
+		}
+		b = append(b, c)
+	}
+	if out := string(b); out != s && utf8.ValidString(out) {
+		return out
+	}
+	return s
+}
+
+// charsetDirective builds decode=. Tags before it that rewrite text, such as lower, replace raw bytes with U+FFFD,
+// so it should come first in a chain, or after tags that only trim.
+func charsetDirective(param string) (transform, error) {
+	enc, ok := charsets[strings.ToLower(param)]
+	if !ok {
+		return nil, fmt.Errorf("unknown charset %q", param)
+	}
+	return func(s string) (string, error) { return decodeCharset(s, enc), nil }, nil
+}
@@ -0,0 +1,49 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestDecodeCharset() {
+	assert := assert.New(t.T())
+
+	for tags, tests := range map[string]map[string]string{
+		"decode=latin1": {
+			"caf\xe9":     "café",
+			"cafÃ©":       "café",
+			"café":        "café",
+			"plain ascii": "plain ascii",
+			"naïve ©":     "naïve ©",
+			"日本":          "日本",
+		},
+		"decode=windows-1252": {
+			"\x93quoted\x94 \x80": "“quoted” €",
+			"â€œquotedâ€\u009d":   "“quoted”",
+			"GrÃ¼ÃŸe aus KÃ¶ln":   "Grüße aus Köln",
+			"itâ€™s":              "it’s",
+		},
+	} {
+		p, err := Compile(tags)
+		if !assert.NoError(err) {
+			continue
+		}
+		for in, want := range tests {
+			out, _ := p.Apply(in)
+			assert.Equal(want, out, "%s: %q", tags, in)
+		}
+	}
+
+	p, err := Compile("trim,decode=latin1")
+	assert.NoError(err)
+	out, _ := p.Apply(" caf\xe9 ")
+	assert.Equal("café", out, "Tags before decode= should leave the bytes for it to decode")
+	p, err = Compile("lower,decode=latin1")
+	assert.NoError(err)
+	out, _ = p.Apply("CAF\xc9")
+	assert.Equal("caf\uFFFD", out, "Tags that rewrite text before decode= replace the bytes it would decode")
+
+	_, err = Compile("decode=ebcdic")
+	assert.EqualError(err, `decode=ebcdic: unknown charset "ebcdic"`)
+}
@@ -0,0 +1,150 @@
+// Command conform applies a rules file to JSON, NDJSON or CSV on stdin and writes the conformed records to stdout.
+//
+// The rules file is a JSON object mapping field names to tags, the same tags used in struct fields:
+//
+//	{"email": "trim,email", "name": "trim,name"}
+//
+// JSON keys are matched at any depth, and CSV columns by their header. Fields without a rule are copied as they are.
+//
+// Usage:
+//
+//	conform -rules rules.json [-format json|ndjson|csv] [-strict] < in > out
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/leebenson/conform"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "conform:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, in io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("conform", flag.ContinueOnError)
+	rulesFile := flags.String("rules", "", "path to a JSON file mapping field names to tags")
+	format := flags.String("format", "ndjson", "input and output format: json, ndjson or csv")
+	strict := flags.Bool("strict", false, "fail on the first value a tag can't be applied to, instead of leaving it as it is")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *rulesFile == "" {
+		return errors.New("-rules is required")
+	}
+
+	rules, err := readRules(*rulesFile)
+	if err != nil {
+		return err
+	}
+	conform.Strict = *strict
+
+	switch *format {
+	case "json":
+		return conformJSON(rules, in, out, false)
+	case "ndjson":
+		return conformJSON(rules, in, out, true)
+	case "csv":
+		return conformCSV(rules, in, out)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+func readRules(path string) (conform.Rules, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules conform.Rules
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// conformJSON reads a single document, or one per line when stream is set
+func conformJSON(rules conform.Rules, in io.Reader, out io.Writer, stream bool) error {
+	dec := json.NewDecoder(in)
+	dec.UseNumber()
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	if !stream {
+		enc.SetIndent("", "  ")
+	}
+
+	for n := 1; ; n++ {
+		var v interface{}
+		if err := dec.Decode(&v); err == io.EOF && (stream || n > 1) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("record %d: %w", n, err)
+		}
+		v, err := conformValue(rules, "", v)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", n, err)
+		}
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		if !stream {
+			return nil
+		}
+	}
+}
+
+// conformValue applies the rule for key to strings, including those in arrays, and recurses into objects
+func conformValue(rules conform.Rules, key string, v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return rules.Apply(key, v)
+	case []interface{}:
+		for i, el := range v {
+			el, err := conformValue(rules, key, el)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = el
+		}
+	case map[string]interface{}:
+		for k, el := range v {
+			el, err := conformValue(rules, k, el)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = el
+		}
+	}
+	return v, nil
+}
+
+// conformCSV treats the first row as the header, and applies rules to columns by name
+func conformCSV(rules conform.Rules, in io.Reader, out io.Writer) error {
+	r := conform.CSVReaderByHeader(csv.NewReader(in), rules)
+	w := csv.NewWriter(out)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,64 @@
+//go:build !conform_minimal
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRules(t *testing.T, rules string) string {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNDJSON(t *testing.T) {
+	rules := writeRules(t, `{"email": "trim,email", "tags": "lower"}`)
+	in := `{"email": " Bob@Example.COM ", "age": 42, "tags": ["A", "B"], "friend": {"email": "AL@X.IO"}}
+{"email": "EVE@X.IO", "note": " <b> "}
+`
+	var out bytes.Buffer
+	err := run([]string{"-rules", rules}, strings.NewReader(in), &out)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"age":42,"email":"Bob@example.com","friend":{"email":"AL@x.io"},"tags":["a","b"]}
+{"email":"EVE@x.io","note":" <b> "}
+`, out.String())
+}
+
+func TestJSON(t *testing.T) {
+	rules := writeRules(t, `{"name": "trim,name"}`)
+	var out bytes.Buffer
+	err := run([]string{"-rules", rules, "-format", "json"}, strings.NewReader(`[{"name": " ann  o'brien "}]`), &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n  {\n    \"name\": \"Ann O'Brien\"\n  }\n]\n", out.String())
+}
+
+func TestCSV(t *testing.T) {
+	rules := writeRules(t, `{"price": "thousands_strip,decimal=2"}`)
+	in := "sku,price\nA1,\"1,250.5\"\nB2,lots\n"
+
+	var out bytes.Buffer
+	err := run([]string{"-rules", rules, "-format", "csv"}, strings.NewReader(in), &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "sku,price\nA1,1250.50\nB2,lots\n", out.String())
+
+	defer func() { conform.Strict = false }()
+	err = run([]string{"-rules", rules, "-format", "csv", "-strict"}, strings.NewReader(in), &out)
+	assert.EqualError(t, err, `line 3: price: decimal=2: "lots" is not a decimal number`)
+}
+
+func TestBadRules(t *testing.T) {
+	rules := writeRules(t, `{"email": "trim,emial"}`)
+	err := run([]string{"-rules", rules}, strings.NewReader(""), &bytes.Buffer{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `email: emial: unknown tag "emial"`)
+}
@@ -0,0 +1,67 @@
+//go:build js && wasm
+
+// Command conformwasm exposes conform to JavaScript, so browsers can run the same code as the server when
+// previewing input. Once loaded, it defines a global conform object:
+//
+//	conform.applyChain("  Hello World ", "trim,lower") // "hello world"
+//
+// Unknown tags and invalid parameters throw an Error. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o conform.wasm ./cmd/conformwasm
+//
+// and load it with the wasm_exec.js shipped in $(go env GOROOT)/lib/wasm.
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/leebenson/conform"
+)
+
+// maxPipelines bounds the pipelines cache, as chains can come from anywhere a page likes
+const maxPipelines = 256
+
+// pipelines caches compiled chains. When full, a random entry is dropped to make room. JavaScript calls into Go
+// one at a time, so it needs no lock.
+var pipelines = map[string]conform.Pipeline{}
+
+func main() {
+	// funcs can't throw, so apply returns [value, error] and a JavaScript wrapper throws the error
+	throwing := js.Global().Get("Function").New("apply", `return (s, tags) => {
+  const [value, error] = apply(String(s), String(tags));
+  if (error) throw new Error(error);
+  return value;
+}`)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("applyChain", throwing.Invoke(js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		value, err := applyChain(args[0].String(), args[1].String())
+		if err != nil {
+			return []interface{}{value, err.Error()}
+		}
+		return []interface{}{value, nil}
+	})))
+	js.Global().Set("conform", obj)
+
+	// keep the funcs callable
+	select {}
+}
+
+// applyChain compiles tags, a comma separated chain such as "trim,lower", and applies them to s
+func applyChain(s, tags string) (string, error) {
+	p, ok := pipelines[tags]
+	if !ok {
+		var err error
+		if p, err = conform.Compile(tags); err != nil {
+			return s, err
+		}
+		if len(pipelines) >= maxPipelines {
+			for k := range pipelines {
+				delete(pipelines, k)
+				break
+			}
+		}
+		pipelines[tags] = p
+	}
+	return p.Apply(s)
+}
@@ -0,0 +1,29 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyChain(t *testing.T) {
+	out, err := applyChain("  Hello World ", "trim,lower")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", out)
+
+	out, err = applyChain("CamelCase", "slug")
+	assert.NoError(t, err)
+	assert.Equal(t, "camel-case", out)
+
+	_, err = applyChain("x", "trim,nope")
+	assert.EqualError(t, err, `nope: unknown tag "nope"`)
+
+	for i := 0; i < maxPipelines+10; i++ {
+		_, err = applyChain("x", "wrap="+strconv.Itoa(i+1))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, pipelines, maxPipelines, "The cache should hold no more than its maximum")
+}
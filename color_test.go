@@ -0,0 +1,33 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestHexColor() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		"#ABC":               "#aabbcc",
+		"abc":                "#aabbcc",
+		" #FF8800 ":          "#ff8800",
+		"rgb(255,0,0)":       "#ff0000",
+		"RGB( 0, 128, 255 )": "#0080ff",
+		"rgb(100%, 50%, 0%)": "#ff8000",
+		"rgb(0 0 0)":         "#000000",
+		"RED":                "#ff0000",
+		"RebeccaPurple":      "#663399",
+		"rgb(256,0,0)":       "rgb(256,0,0)",
+		"#abcd":              "#abcd",
+		"reddish":            "reddish",
+	} {
+		var s struct {
+			Color string `conform:"hexcolor"`
+		}
+		s.Color = in
+		Strings(&s)
+		assert.Equal(expected, s.Color, "hexcolor of %q", in)
+	}
+}
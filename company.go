@@ -0,0 +1,124 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// companySuffixes map legal suffixes, keyed by companyKey, to how they're written
+var companySuffixes = struct {
+	sync.RWMutex
+	m map[string]string
+}{m: map[string]string{}}
+
+func init() {
+	RegisterCompanySuffixes(map[string]string{
+		"incorporated":                  "Inc.",
+		"inc":                           "Inc.",
+		"corporation":                   "Corp.",
+		"corp":                          "Corp.",
+		"company":                       "Co.",
+		"co":                            "Co.",
+		"limited":                       "Ltd.",
+		"ltd":                           "Ltd.",
+		"limited liability company":     "LLC",
+		"llc":                           "LLC",
+		"limited liability partnership": "LLP",
+		"llp":                           "LLP",
+		"limited partnership":           "LP",
+		"lp":                            "LP",
+		"public limited company":        "PLC",
+		"plc":                           "PLC",
+		"proprietary":                   "Pty",
+		"pty":                           "Pty",
+		"gmbh":                          "GmbH",
+		"ag":                            "AG",
+		"sa":                            "S.A.",
+		"bv":                            "B.V.",
+		"nv":                            "N.V.",
+	})
+}
+
+// RegisterCompanySuffixes adds legal suffixes used by the "company" tag, mapping how they may be typed to how
+// they should be written, e.g. "sarl": "SARL". Matching ignores case and full stops, and the written form
+// always matches itself.
+func RegisterCompanySuffixes(suffixes map[string]string) {
+	companySuffixes.Lock()
+	defer companySuffixes.Unlock()
+	for typed, written := range suffixes {
+		companySuffixes.m[companyKey(typed)] = written
+		companySuffixes.m[companyKey(written)] = written
+	}
+}
+
+// companySuffix returns how the legal suffix typed is written, if it is one
+func companySuffix(typed string) (string, bool) {
+	companySuffixes.RLock()
+	defer companySuffixes.RUnlock()
+	written, ok := companySuffixes.m[companyKey(typed)]
+	return written, ok
+}
+
+func companyKey(s string) string {
+	return strings.ToLower(strings.Trim(strings.ReplaceAll(s, ".", ""), ","))
+}
+
+// company normalises a company name. Runs of whitespace become single spaces and the legal suffixes at the end
+// are written one way. Names typed all in one case, suffixes included, are title cased, keeping small words such as
+// "of" lowercase; names in mixed case, such as "eBay", or in a different case to their suffixes, such as "IBM corp",
+// are left as they were typed.
+func company(s string) string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return ""
+	}
+
+	// match suffixes from the end, longest first, never swallowing the first word
+	end := len(words)
+	var suffixes []string
+	for end > 1 {
+		matched := false
+		for n := 3; n > 0 && !matched; n-- {
+			if end-n < 1 {
+				continue
+			}
+			if written, ok := companySuffix(strings.Join(words[end-n:end], " ")); ok {
+				if strings.HasSuffix(words[end-1], ",") {
+					written += ","
+				}
+				suffixes = append([]string{written}, suffixes...)
+				end -= n
+				matched = true
+			}
+		}
+		if !matched {
+			break
+		}
+	}
+
+	name := words[:end]
+	if !mixedCase(s) {
+		for i, w := range name {
+			lower := strings.ToLower(w)
+			if i > 0 && isSmallWord("en", strings.TrimFunc(lower, unicode.IsPunct)) {
+				name[i] = lower
+			} else {
+				name[i] = ucFirst(lower)
+			}
+		}
+	}
+	return strings.Join(append(name, suffixes...), " ")
+}
+
+// mixedCase reports whether s has both upper and lower case letters
+func mixedCase(s string) bool {
+	upper, lower := false, false
+	for _, r := range s {
+		upper = upper || unicode.IsUpper(r)
+		lower = lower || unicode.IsLower(r)
+	}
+	return upper && lower
+}
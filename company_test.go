@@ -0,0 +1,43 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestCompany() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"ACME WIDGETS INCORPORATED":         "Acme Widgets Inc.",
+		"  bank  of america corporation":    "Bank of America Corp.",
+		"eBay inc":                          "eBay Inc.",
+		"IBM corp":                          "IBM Corp.",
+		"Acme, inc":                         "Acme, Inc.",
+		"acme holdings co., ltd":            "Acme Holdings Co., Ltd.",
+		"Widgets Limited Liability Company": "Widgets LLC",
+		"siemens ag":                        "Siemens AG",
+		"the company store":                 "The Company Store",
+		"limited":                           "Limited",
+		"":                                  "",
+	} {
+		assert.Equal(want, company(in), in)
+	}
+
+	RegisterCompanySuffixes(map[string]string{"sarl": "SARL"})
+	assert.Equal("Dupont SARL", company("dupont s.a.r.l."))
+
+	// registering while names are being normalised is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterCompanySuffixes(map[string]string{"kk": "K.K."})
+	}()
+	company("sony kk")
+	wg.Wait()
+	assert.Equal("Sony K.K.", company("sony kk"))
+}
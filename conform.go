@@ -2,31 +2,23 @@ package conform
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"html/template"
 	"reflect"
-	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
-
-	"github.com/etgryphon/stringUp"
 )
 
-type x map[string]string
-
 type sanitizer func(string) string
 
-var sanitizers = map[string]sanitizer{}
+// defaultRegistry holds the sanitizers added with AddSanitizer
+var defaultRegistry = NewRegistry()
 
-var patterns = map[string]*regexp.Regexp{
-	"numbers":    regexp.MustCompile("[0-9]"),
-	"nonNumbers": regexp.MustCompile("[^0-9]"),
-	"alpha":      regexp.MustCompile("[\\pL]"),
-	"nonAlpha":   regexp.MustCompile("[^\\pL]"),
-	"name":       regexp.MustCompile("[\\p{L}]([\\p{L}|[:space:]|\\-|\\']*[\\p{L}])*"),
-}
+// Strict makes Strings return an error when a tag can't be applied to a value, such as "decimal=2" on "abc".
+// By default those values are left as they are.
+var Strict = false
 
 // a valid email will only have one "@", but let's treat the last "@" as the domain part separator
 func emailLocalPart(s string) string {
@@ -54,7 +46,9 @@ func camelTo(s, sep string) string {
 	var result string
 	var words []string
 	var lastPos int
-	rs := []rune(s)
+	buf := getRunes(s)
+	defer putRunes(buf)
+	rs := *buf
 
 	for i := 0; i < len(rs); i++ {
 		if i > 0 && unicode.IsUpper(rs[i]) {
@@ -149,40 +143,109 @@ func ucFirst(s string) string {
 	return buf.String()
 }
 
-func onlyNumbers(s string) string {
-	return patterns["nonNumbers"].ReplaceAllLiteralString(s, "")
-}
-
-func stripNumbers(s string) string {
-	return patterns["numbers"].ReplaceAllLiteralString(s, "")
-}
-
-func onlyAlpha(s string) string {
-	return patterns["nonAlpha"].ReplaceAllLiteralString(s, "")
+// sentence lowercases s, then capitalises the first letter of each sentence, where sentences end with ".", "!"
+// or "?" followed by whitespace
+func sentence(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	start, ended := true, false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case start && unicode.IsLetter(r):
+			r = unicode.ToUpper(r)
+			start = false
+		case strings.ContainsRune(".!?", r):
+			ended = true
+		case unicode.IsSpace(r):
+			if ended {
+				start = true
+			}
+		default:
+			ended = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
 
-func stripAlpha(s string) string {
-	return patterns["alpha"].ReplaceAllLiteralString(s, "")
+// wrap hard-wraps each line of s at width columns, breaking on word boundaries.
+// Words longer than width are split across lines.
+func wrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	// words and the line being built share buffers from the pool, as each is copied into a string once done with
+	cur, word := getRunes(""), getRunes("")
+	defer putRunes(cur)
+	defer putRunes(word)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		var wrapped []string
+		current := (*cur)[:0]
+		for _, field := range strings.Fields(line) {
+			w := (*word)[:0]
+			for _, r := range field {
+				w = append(w, r)
+			}
+			*word = w
+			for len(w) > width {
+				if len(current) > 0 {
+					wrapped = append(wrapped, string(current))
+					current = current[:0]
+				}
+				wrapped = append(wrapped, string(w[:width]))
+				w = w[width:]
+			}
+			if len(w) == 0 {
+				continue
+			}
+			if len(current) > 0 && len(current)+1+len(w) > width {
+				wrapped = append(wrapped, string(current))
+				current = current[:0]
+			}
+			if len(current) > 0 {
+				current = append(current, ' ')
+			}
+			current = append(current, w...)
+		}
+		*cur = current
+		if len(current) > 0 {
+			wrapped = append(wrapped, string(current))
+		}
+		lines[i] = strings.Join(wrapped, "\n")
+	}
+	return strings.Join(lines, "\n")
 }
 
-func onlyOne(s string, m []x) string {
-	for _, v := range m {
-		for f, r := range v {
-			s = regexp.MustCompile(fmt.Sprintf("%s", f)).ReplaceAllLiteralString(s, r)
+// maxWords keeps the first max words of s, cutting after the last one kept. Whitespace between the words kept is
+// left as it is.
+func maxWords(s string, max int) string {
+	words := 0
+	inWord := false
+	for i, r := range s {
+		switch space := unicode.IsSpace(r); {
+		case space && inWord:
+			inWord = false
+			if words == max {
+				return s[:i]
+			}
+		case !space && !inWord:
+			inWord = true
+			words++
 		}
 	}
-	return s
+	return strings.TrimRightFunc(s, unicode.IsSpace)
 }
 
-func formatName(s string) string {
-	first := onlyOne(strings.ToLower(s), []x{
-		{"[^\\pL-\\s']": ""}, // cut off everything except [ alpha, hyphen, whitespace, apostrophe]
-		{"\\s{2,}": " "},     // trim more than two whitespaces to one
-		{"-{2,}": "-"},       // trim more than two hyphens to one
-		{"'{2,}": "'"},       // trim more than two apostrophes to one
-		{"( )*-( )*": "-"},   // trim enclosing whitespaces around hyphen
-	})
-	return strings.Title(patterns["name"].FindString(first))
+// boolStr canonicalises boolean-ish strings to "true" or "false", leaving anything else as it is
+func boolStr(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "t", "yes", "y", "1", "on":
+		return "true"
+	case "false", "f", "no", "n", "0", "off":
+		return "false"
+	}
+	return s
 }
 
 func getSliceElemType(t reflect.Type) reflect.Type {
@@ -196,172 +259,89 @@ func getSliceElemType(t reflect.Type) reflect.Type {
 	return elType
 }
 
-func transformValue(tags string, val reflect.Value) reflect.Value {
-	if val.Kind() == reflect.Ptr && val.IsNil() {
-		return val
-	}
-
-	var oldStr string
-	if val.Kind() == reflect.Ptr {
-		oldStr = val.Elem().String()
-	} else {
-		oldStr = val.String()
-	}
-
-	newStr := transformString(oldStr, tags)
-
-	var newVal reflect.Value
-	if val.Kind() == reflect.Ptr {
-		newVal = reflect.ValueOf(&newStr)
-	} else {
-		newVal = reflect.ValueOf(newStr)
-	}
-
-	return newVal.Convert(val.Type())
-}
-
 func isStringLike(t reflect.Type) bool {
 	str := ""
 	return (t.ConvertibleTo(reflect.TypeOf(str)) && reflect.TypeOf(str).ConvertibleTo(t)) ||
 		(t.ConvertibleTo(reflect.TypeOf(&str)) && reflect.TypeOf(&str).ConvertibleTo(t))
 }
 
-// Strings conforms strings based on reflection tags
+// Strings conforms strings based on reflection tags. iface points to a struct, or to a slice or map of structs.
 func Strings(iface interface{}) error {
-	ifv := reflect.ValueOf(iface)
-	if ifv.Kind() != reflect.Ptr {
-		return errors.New("Not a pointer")
-	}
-	ift := reflect.Indirect(ifv).Type()
-	if ift.Kind() != reflect.Struct {
+	return StringsContext(context.Background(), iface)
+}
+
+// String conforms the string s points to with a chain of tags, as a struct field tagged with them would be, for
+// single values that aren't in a struct. Unknown tags are skipped, and as there's no struct to give them, func
+// tags fail.
+func String(s *string, tags string) error {
+	if s == nil {
 		return nil
 	}
-	for i := 0; i < ift.NumField(); i++ {
-		v := ift.Field(i)
-		el := reflect.Indirect(ifv.Elem().FieldByName(v.Name))
-		switch el.Kind() {
-		case reflect.Slice:
-			if el.CanInterface() {
-				elType := getSliceElemType(v.Type)
-
-				// allow strings and string pointers
-				if isStringLike(elType) {
-					tags := v.Tag.Get("conform")
-					if len(tags) <= 0 {
-						continue
-					}
-					for i := 0; i < el.Len(); i++ {
-						el.Index(i).Set(transformValue(tags, el.Index(i)))
-					}
-				} else {
-					val := reflect.ValueOf(el.Interface())
-					for i := 0; i < val.Len(); i++ {
-						elVal := val.Index(i)
-						if elVal.Kind() != reflect.Ptr {
-							elVal = elVal.Addr()
-						}
-						Strings(elVal.Interface())
-					}
-				}
-			}
-		case reflect.Map:
-			if el.CanInterface() {
-				elType := getSliceElemType(v.Type)
-
-				// allow strings and string pointers
-				if isStringLike(elType) {
-					tags := v.Tag.Get("conform")
-					val := reflect.ValueOf(el.Interface())
-					for _, key := range val.MapKeys() {
-						el.SetMapIndex(key, transformValue(tags, el.MapIndex(key)))
-					}
-				} else {
-					val := reflect.ValueOf(el.Interface())
-					for _, key := range val.MapKeys() {
-						mapValue := val.MapIndex(key)
-						mapValuePtr := reflect.New(mapValue.Type())
-						mapValuePtr.Elem().Set(mapValue)
-						if mapValuePtr.Elem().CanAddr() {
-							Strings(mapValuePtr.Elem().Addr().Interface())
-						}
-						val.SetMapIndex(key, reflect.Indirect(mapValuePtr))
-					}
-				}
-			}
-		case reflect.Struct:
-			if el.CanAddr() && el.Addr().CanInterface() {
-				// To handle "sql.NullString" we can assume that tags are added to a field of type struct rather than string
-				if tags := v.Tag.Get("conform"); tags != "" && el.CanSet() {
-					field := el.FieldByName("String")
-					str := field.String()
-					field.SetString(transformString(str, tags))
-				} else {
-					Strings(el.Addr().Interface())
-				}
-			}
-		case reflect.String:
-			if el.CanSet() {
-				tags := v.Tag.Get("conform")
-				input := el.String()
-				el.SetString(transformString(input, tags))
-			}
-		}
+	out, err := newWalker(defaultOptions()).transformString(*s, tags)
+	if err != nil {
+		return err
 	}
+	*s = out
 	return nil
 }
 
-func transformString(input, tags string) string {
-	if tags == "" {
-		return input
-	}
-	for _, split := range strings.Split(tags, ",") {
-		switch split {
-		case "trim":
-			input = strings.TrimSpace(input)
-		case "ltrim":
-			input = strings.TrimLeft(input, " ")
-		case "rtrim":
-			input = strings.TrimRight(input, " ")
-		case "lower":
-			input = strings.ToLower(input)
-		case "upper":
-			input = strings.ToUpper(input)
-		case "title":
-			input = strings.Title(input)
-		case "camel":
-			input = stringUp.CamelCase(input)
-		case "snake":
-			input = camelTo(stringUp.CamelCase(input), "_")
-		case "slug":
-			input = camelTo(stringUp.CamelCase(input), "-")
-		case "ucfirst":
-			input = ucFirst(input)
-		case "name":
-			input = formatName(input)
-		case "email":
-			input = email(strings.TrimSpace(input))
-		case "num":
-			input = onlyNumbers(input)
-		case "!num":
-			input = stripNumbers(input)
-		case "alpha":
-			input = onlyAlpha(input)
-		case "!alpha":
-			input = stripAlpha(input)
-		case "!html":
-			input = template.HTMLEscapeString(input)
-		case "!js":
-			input = template.JSEscapeString(input)
-		default:
-			if s, ok := sanitizers[split]; ok {
-				input = s(input)
+// directives are the built in tags, keyed by name
+var directives = map[string]directive{
+	"trim":  plain(strings.TrimSpace),
+	"ltrim": plain(func(s string) string { return strings.TrimLeft(s, " ") }),
+	"rtrim": plain(func(s string) string { return strings.TrimRight(s, " ") }),
+	"trimpunct": plain(func(s string) string {
+		return strings.TrimFunc(s, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSpace(r) })
+	}),
+	"!punct": plain(func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) {
+				return -1
 			}
+			return r
+		}, s)
+	}),
+	"trimchars": func(param string) (transform, error) {
+		if param == "" {
+			return nil, errors.New("missing characters to trim")
 		}
-	}
-	return input
+		return func(s string) (string, error) { return strings.Trim(s, param), nil }, nil
+	},
+	"lower":              plain(strings.ToLower),
+	"upper":              plain(strings.ToUpper),
+	"ucfirst":            plain(ucFirst),
+	"sentence":           plain(sentence),
+	"email":              plain(func(s string) string { return email(strings.TrimSpace(s)) }),
+	"wrap":               withInt(1, wrap),
+	"boolstr":            plain(boolStr),
+	"maxwords":           withInt(1, maxWords),
+	"leading_plus_strip": plain(func(s string) string { return strings.TrimPrefix(s, "+") }),
+	"nobidi":             plain(noBidi),
+	"nocontrol":          plain(noControl),
+	"func":               funcDirective,
+	"squeeze": func(param string) (transform, error) {
+		return func(s string) (string, error) { return squeeze(s, param), nil }, nil
+	},
+	"validutf8": func(param string) (transform, error) {
+		if param != "" && param != "replace" && param != "drop" {
+			return nil, fmt.Errorf("%q is not \"replace\" or \"drop\"", param)
+		}
+		return func(s string) (string, error) { return validUTF8(s, param == "drop"), nil }, nil
+	},
 }
 
+// fullTags are the tags full.go adds, which minimal builds leave out. They're listed here so minimal builds report
+// them as left out rather than unknown, as fields relying on them, such as for escaping, mustn't be skipped quietly.
+var fullTags = strings.Fields(`
+	!alpha !attr !css !csv !header !html !js !ldap !like !num !shell !url !xpath aba alpha camel company
+	confusables country currency decimal decimal_sep decode digits_to_words duration ean ein enum ethaddr excerpt
+	handle hashtags hexcolor ip isbn issn key_brackets key_flatten langtag latlon lookup mac md_normalize
+	mention_strip metaphone mrz name nomarkdown num plate plural postal searchkey singular slug snake sortcode
+	soundex ssn swift thousands_strip title token translit tzname url_notracking url_public url_samehost usstate
+	vatid vin words_to_digits
+`)
+
 // AddSanitizer associates a sanitizer with a key, which can be used in a Struct tag
 func AddSanitizer(key string, s sanitizer) {
-	sanitizers[key] = s
+	defaultRegistry.AddSanitizer(key, s)
 }
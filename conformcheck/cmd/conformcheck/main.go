@@ -0,0 +1,15 @@
+// Command conformcheck checks conform struct tags for unknown tags, invalid parameters and fields they can't apply to.
+//
+// Usage:
+//
+//	conformcheck [-sanitizers=name,...] ./...
+package main
+
+import (
+	"github.com/leebenson/conform/conformcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(conformcheck.Analyzer)
+}
@@ -0,0 +1,136 @@
+// Package conformcheck defines an Analyzer that checks conform struct tags,
+// so typos are caught in CI instead of being silently skipped at runtime.
+package conformcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/leebenson/conform"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports unknown tags, invalid parameters and conform tags on fields they can't apply to
+var Analyzer = &analysis.Analyzer{
+	Name:     "conformcheck",
+	Doc:      "check conform struct tags for unknown tags, invalid parameters and fields they can't apply to",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// sanitizers lists the custom sanitizers added at runtime with conform.AddSanitizer
+var sanitizers string
+
+func init() {
+	Analyzer.Flags.StringVar(&sanitizers, "sanitizers", "", "comma separated names of custom sanitizers added with conform.AddSanitizer")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	custom := map[string]bool{}
+	for _, name := range strings.Split(sanitizers, ",") {
+		custom[name] = true
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			tags, ok := reflect.StructTag(tag).Lookup("conform")
+			if !ok || isVersionMarker(field, tags) {
+				continue
+			}
+			checkTags(pass, field, tags, custom)
+			checkType(pass, field, tags)
+		}
+	})
+	return nil, nil
+}
+
+// checkTags compiles each tag on its own, so every problem in the chain is reported
+func checkTags(pass *analysis.Pass, field *ast.Field, tags string, custom map[string]bool) {
+	for _, tag := range conform.SplitTags(tags) {
+		if custom[tag] {
+			continue
+		}
+		if _, err := conform.Compile(tag); err != nil {
+			pass.Reportf(field.Tag.Pos(), "conform: %v", err)
+		}
+	}
+}
+
+func checkType(pass *analysis.Pass, field *ast.Field, tags string) {
+	t := pass.TypesInfo.TypeOf(field.Type)
+	if t == nil {
+		return
+	}
+	t = deref(t)
+
+	collection := false
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		collection, t = true, deref(u.Elem())
+	case *types.Array:
+		collection, t = true, deref(u.Elem())
+	case *types.Map:
+		collection, t = true, deref(u.Elem())
+	}
+
+	if !collection && hasTag(tags, "dive") {
+		pass.Reportf(field.Tag.Pos(), "conform: dive used on %s, which isn't a slice or map", pass.TypesInfo.TypeOf(field.Type))
+	}
+	if !isStringLike(t) && !(!collection && hasStringField(t)) {
+		pass.Reportf(field.Tag.Pos(), "conform: tags have no effect on %s", pass.TypesInfo.TypeOf(field.Type))
+	}
+}
+
+// isVersionMarker reports whether field is a blank field marking its struct with a rule version, which isn't a
+// chain of tags
+func isVersionMarker(field *ast.Field, tags string) bool {
+	return len(field.Names) == 1 && field.Names[0].Name == "_" && strings.HasPrefix(tags, "version=")
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+func hasTag(tags, name string) bool {
+	for _, tag := range conform.SplitTags(tags) {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isStringLike(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// hasStringField allows structs like sql.NullString, whose String field is conformed
+func hasStringField(t types.Type) bool {
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < s.NumFields(); i++ {
+		if f := s.Field(i); f.Name() == "String" && isStringLike(f.Type()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+//go:build !conform_minimal
+
+package conformcheck_test
+
+import (
+	"testing"
+
+	"github.com/leebenson/conform/conformcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := conformcheck.Analyzer.Flags.Set("sanitizers", "shout"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), conformcheck.Analyzer, "a")
+}
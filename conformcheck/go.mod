@@ -0,0 +1,20 @@
+module github.com/leebenson/conform/conformcheck
+
+go 1.22.0
+
+require (
+	github.com/leebenson/conform v0.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/etgryphon/stringUp v0.0.0-20121020160746-31534ccd8cac // indirect
+	golang.org/x/crypto v0.24.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+)
+
+// the analyzer is developed alongside conform, so builds against the copy next to it
+replace github.com/leebenson/conform => ../
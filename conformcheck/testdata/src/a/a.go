@@ -0,0 +1,32 @@
+package a
+
+type String string
+
+type NullString struct {
+	String string
+	Valid  bool
+}
+
+type Cat struct {
+	Name string `conform:"trim"`
+}
+
+type Form struct {
+	_        struct{}          `conform:"version=v1"`
+	Name     string            `conform:"trim,name"`
+	Custom   String            `conform:"trim,shout"`
+	Pointer  *string           `conform:"trim"`
+	Tags     []string          `conform:"dive,hashtags"`
+	Labels   map[string]string `conform:"lower"`
+	Nullable NullString        `conform:"trim"`
+	Untagged int
+	Other    string `json:"other"`
+
+	Typo    string   `conform:"trim,lowr"`       // want `conform: lowr: unknown tag "lowr"`
+	BadWrap string   `conform:"wrap=wide"`       // want `conform: wrap=wide: "wide" is not a whole number of at least 1`
+	Both    string   `conform:"trimm,postal=XX"` // want `conform: trimm: unknown tag "trimm"` `conform: postal=XX: unknown country "XX"`
+	Age     int      `conform:"trim"`            // want `conform: tags have no effect on int`
+	Cats    []Cat    `conform:"trim"`            // want `conform: tags have no effect on \[\]a.Cat`
+	Dive    string   `conform:"dive,hashtags"`   // want `conform: dive used on string, which isn't a slice or map`
+	Ints    []*int64 `conform:"dive,trim"`       // want `conform: tags have no effect on \[\]\*int64`
+}
@@ -0,0 +1,28 @@
+// Package conformecho provides an echo Binder that conforms the values it binds:
+//
+//	e := echo.New()
+//	e.Binder = conformecho.Binder{}
+package conformecho
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/leebenson/conform"
+)
+
+// Binder wraps another echo.Binder, echo.DefaultBinder if nil, and conforms each value it binds. As echo
+// validates separately, c.Validate sees the conformed values.
+type Binder struct {
+	echo.Binder
+}
+
+// Bind binds i with the wrapped binder, then conforms it
+func (b Binder) Bind(i interface{}, c echo.Context) error {
+	inner := b.Binder
+	if inner == nil {
+		inner = &echo.DefaultBinder{}
+	}
+	if err := inner.Bind(i, c); err != nil {
+		return err
+	}
+	return conform.StringsContext(c.Request().Context(), i)
+}
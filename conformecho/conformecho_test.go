@@ -0,0 +1,38 @@
+package conformecho
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type signup struct {
+	Name  string `json:"name" query:"name" conform:"trim,name"`
+	Email string `json:"email" conform:"trim,email"`
+}
+
+func TestBinder(t *testing.T) {
+	e := echo.New()
+	e.Binder = Binder{}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": " ann ", "email": " ANN@X.IO "}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	var s signup
+	assert.NoError(t, c.Bind(&s))
+	assert.Equal(t, "Ann", s.Name)
+	assert.Equal(t, "ANN@x.io", s.Email)
+
+	s = signup{}
+	c = e.NewContext(httptest.NewRequest("GET", "/?name=+bob+", nil), httptest.NewRecorder())
+	assert.NoError(t, c.Bind(&s))
+	assert.Equal(t, "Bob", s.Name)
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"email": 1}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	assert.Error(t, e.NewContext(req, httptest.NewRecorder()).Bind(&s))
+}
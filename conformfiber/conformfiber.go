@@ -0,0 +1,28 @@
+// Package conformfiber wraps fiber's parsers so the values they parse are conformed:
+//
+//	var req SignupRequest
+//	if err := conformfiber.BodyParser(c, &req); err != nil {
+//		// ...
+//	}
+package conformfiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/leebenson/conform"
+)
+
+// BodyParser parses the request body into out with c.BodyParser, then conforms it
+func BodyParser(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		return err
+	}
+	return conform.Strings(out)
+}
+
+// QueryParser parses the query string into out with c.QueryParser, then conforms it
+func QueryParser(c *fiber.Ctx, out interface{}) error {
+	if err := c.QueryParser(out); err != nil {
+		return err
+	}
+	return conform.Strings(out)
+}
@@ -0,0 +1,39 @@
+package conformfiber
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type signup struct {
+	Name  string `json:"name" query:"name" conform:"trim,name"`
+	Email string `json:"email" conform:"trim,email"`
+}
+
+func TestParsers(t *testing.T) {
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		var s signup
+		if err := BodyParser(c, &s); err != nil {
+			return err
+		}
+		if err := QueryParser(c, &s); err != nil {
+			return err
+		}
+		return c.SendString(s.Name + " " + s.Email)
+	})
+
+	req := httptest.NewRequest("POST", "/?name=+ann+", strings.NewReader(`{"email": " ANN@X.IO "}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "Ann ANN@x.io", string(body))
+}
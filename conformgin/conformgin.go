@@ -0,0 +1,104 @@
+// Package conformgin provides gin bindings that conform the values they bind, before they're validated:
+//
+//	var req SignupRequest
+//	if err := c.ShouldBindWith(&req, conformgin.JSON); err != nil {
+//		// ...
+//	}
+package conformgin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/leebenson/conform"
+)
+
+// defaultMemory matches the limit gin uses for multipart forms
+const defaultMemory = 32 << 20
+
+var (
+	// JSON decodes the request body as JSON
+	JSON binding.BindingBody = jsonBinding{}
+	// Form binds the query string and urlencoded or multipart form bodies
+	Form binding.Binding = formBinding{}
+	// Query binds the query string
+	Query binding.Binding = queryBinding{}
+)
+
+type jsonBinding struct{}
+
+func (jsonBinding) Name() string {
+	return "json"
+}
+
+func (jsonBinding) Bind(req *http.Request, obj interface{}) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	return decodeJSON(req.Body, obj)
+}
+
+func (jsonBinding) BindBody(body []byte, obj interface{}) error {
+	return decodeJSON(bytes.NewReader(body), obj)
+}
+
+func decodeJSON(r io.Reader, obj interface{}) error {
+	decoder := json.NewDecoder(r)
+	if binding.EnableDecoderUseNumber {
+		decoder.UseNumber()
+	}
+	if binding.EnableDecoderDisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	return conformAndValidate(obj)
+}
+
+type formBinding struct{}
+
+func (formBinding) Name() string {
+	return "form"
+}
+
+func (formBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
+	if err := req.ParseMultipartForm(defaultMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return err
+	}
+	if err := binding.MapFormWithTag(obj, req.Form, "form"); err != nil {
+		return err
+	}
+	return conformAndValidate(obj)
+}
+
+type queryBinding struct{}
+
+func (queryBinding) Name() string {
+	return "query"
+}
+
+func (queryBinding) Bind(req *http.Request, obj interface{}) error {
+	if err := binding.MapFormWithTag(obj, req.URL.Query(), "form"); err != nil {
+		return err
+	}
+	return conformAndValidate(obj)
+}
+
+// conformAndValidate conforms obj, then validates it with gin's validator, so `binding` tags see conformed values
+func conformAndValidate(obj interface{}) error {
+	if err := conform.Strings(obj); err != nil {
+		return err
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,59 @@
+package conformgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type signup struct {
+	Name  string `json:"name" form:"name" conform:"trim,name"`
+	Email string `json:"email" form:"email" conform:"trim,email" binding:"required,email"`
+}
+
+func context(req *http.Request) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	return c
+}
+
+func TestJSON(t *testing.T) {
+	c := context(httptest.NewRequest("POST", "/", strings.NewReader(`{"name": " ann ", "email": " ANN@X.IO "}`)))
+
+	var req signup
+	assert.NoError(t, c.ShouldBindWith(&req, JSON), "Validation should see the conformed email")
+	assert.Equal(t, "Ann", req.Name)
+	assert.Equal(t, "ANN@x.io", req.Email)
+
+	c = context(httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "ann"}`)))
+	assert.Error(t, c.ShouldBindWith(&signup{}, JSON))
+}
+
+func TestBindBody(t *testing.T) {
+	c := context(httptest.NewRequest("POST", "/", strings.NewReader(`{"email": " ann@x.io "}`)))
+
+	var req signup
+	assert.NoError(t, c.ShouldBindBodyWith(&req, JSON))
+	assert.Equal(t, "ann@x.io", req.Email)
+}
+
+func TestForm(t *testing.T) {
+	req := httptest.NewRequest("POST", "/?name=+bob+", strings.NewReader("email=+BOB%40X.IO+"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var s signup
+	assert.NoError(t, context(req).ShouldBindWith(&s, Form))
+	assert.Equal(t, "Bob", s.Name)
+	assert.Equal(t, "BOB@x.io", s.Email)
+}
+
+func TestQuery(t *testing.T) {
+	var s signup
+	assert.NoError(t, context(httptest.NewRequest("GET", "/?name=+eve+&email=eve%40x.io", nil)).ShouldBindWith(&s, Query))
+	assert.Equal(t, "Eve", s.Name)
+}
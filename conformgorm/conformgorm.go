@@ -0,0 +1,50 @@
+// Package conformgorm conforms GORM models before they're created or updated, so code paths that skip the HTTP
+// layer can't persist unconformed data. Register it once per DB handle:
+//
+//	db, err := gorm.Open(dialector, &gorm.Config{})
+//	if err := conformgorm.Register(db); err != nil {
+//		// ...
+//	}
+package conformgorm
+
+import (
+	"reflect"
+
+	"github.com/leebenson/conform"
+	"gorm.io/gorm"
+)
+
+// Name is the name the callbacks are registered under
+const Name = "conform:strings"
+
+// Register adds callbacks that conform models after their BeforeSave, BeforeCreate and BeforeUpdate hooks run,
+// and before they're written. Updates from maps, rather than models, are left alone.
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:before_create").Before("gorm:create").Register(Name, conformModel); err != nil {
+		return err
+	}
+	return db.Callback().Update().After("gorm:before_update").Before("gorm:update").Register(Name, conformModel)
+}
+
+func conformModel(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Struct:
+		if rv.CanAddr() {
+			db.AddError(conform.StringsContext(db.Statement.Context, rv.Addr().Interface()))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			el := reflect.Indirect(rv.Index(i))
+			if el.Kind() == reflect.Struct && el.CanAddr() {
+				if err := conform.StringsContext(db.Statement.Context, el.Addr().Interface()); err != nil {
+					db.AddError(err)
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package conformgorm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+type User struct {
+	ID    uint
+	Name  string `conform:"trim,name"`
+	Email string `conform:"trim,email"`
+	Price string `conform:"decimal=2"`
+}
+
+func open(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestCreate(t *testing.T) {
+	db := open(t)
+
+	u := User{Name: " ann ", Email: " ANN@X.IO "}
+	assert.NoError(t, db.Create(&u).Error)
+	assert.Equal(t, "Ann", u.Name)
+	assert.Equal(t, "ANN@x.io", u.Email)
+
+	users := []*User{{Name: " bob "}, {Name: " eve "}}
+	assert.NoError(t, db.Create(&users).Error)
+	assert.Equal(t, "Bob", users[0].Name)
+	assert.Equal(t, "Eve", users[1].Name)
+}
+
+func TestUpdate(t *testing.T) {
+	db := open(t)
+
+	u := User{ID: 1, Name: " al "}
+	assert.NoError(t, db.Save(&u).Error)
+	assert.Equal(t, "Al", u.Name)
+
+	assert.NoError(t, db.Model(&u).Updates(map[string]interface{}{"name": " raw "}).Error, "Map updates should be left alone")
+}
+
+func TestStrict(t *testing.T) {
+	conform.Strict = true
+	defer func() { conform.Strict = false }()
+
+	err := open(t).Create(&User{Price: "lots"}).Error
+	var stepErr *conform.StepError
+	assert.True(t, errors.As(err, &stepErr))
+}
@@ -0,0 +1,24 @@
+module github.com/leebenson/conform/conformgorm
+
+go 1.22.0
+
+require (
+	github.com/leebenson/conform v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.9.0
+	gorm.io/gorm v1.25.12
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/etgryphon/stringUp v0.0.0-20121020160746-31534ccd8cac // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.28.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// the package is developed alongside conform, so builds against the copy next to it
+replace github.com/leebenson/conform => ../
@@ -0,0 +1,71 @@
+// Package conformgql conforms GraphQL resolver arguments, which bypass HTTP body middleware. With gqlgen, add it
+// as a field middleware, which runs after the arguments are unmarshalled and before the resolver:
+//
+//	srv.AroundFields(func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+//		if err := conformgql.Args(graphql.GetFieldContext(ctx).Args); err != nil {
+//			return nil, err
+//		}
+//		return next(ctx)
+//	})
+//
+// The package doesn't import gqlgen itself, so it works with any version, or any server that hands resolvers
+// their arguments as a map.
+package conformgql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/leebenson/conform"
+)
+
+// Args conforms the input objects in args, in place. Structs, pointers to structs and slices of either are
+// conformed, and anything else is left as it is.
+func Args(args map[string]interface{}) error {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v, err := conformArg(reflect.ValueOf(args[name]))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if v.IsValid() {
+			args[name] = v.Interface()
+		}
+	}
+	return nil
+}
+
+// conformArg returns the conformed value, or the zero Value when it's been conformed in place or skipped
+func conformArg(v reflect.Value) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			return reflect.Value{}, conform.Strings(v.Interface())
+		}
+	case reflect.Struct:
+		// arguments are passed by value, so conform a copy and hand it back
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		if err := conform.Strings(p.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+		return p.Elem(), nil
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			el := v.Index(i)
+			if el.Kind() == reflect.Struct {
+				el = el.Addr()
+			}
+			if _, err := conformArg(el); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	}
+	return reflect.Value{}, nil
+}
@@ -0,0 +1,48 @@
+//go:build !conform_minimal
+
+package conformgql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+)
+
+type newUser struct {
+	Name  string `conform:"trim,name"`
+	Email string `conform:"trim,email"`
+}
+
+func TestArgs(t *testing.T) {
+	args := map[string]interface{}{
+		"input":   newUser{Name: " ann ", Email: " ANN@X.IO "},
+		"patch":   &newUser{Name: " bob "},
+		"invites": []newUser{{Name: " eve "}},
+		"others":  []*newUser{{Name: " al "}, nil},
+		"id":      " 42 ",
+		"none":    nil,
+	}
+	assert.NoError(t, Args(args))
+
+	assert.Equal(t, newUser{Name: "Ann", Email: "ANN@x.io"}, args["input"])
+	assert.Equal(t, "Bob", args["patch"].(*newUser).Name)
+	assert.Equal(t, "Eve", args["invites"].([]newUser)[0].Name)
+	assert.Equal(t, "Al", args["others"].([]*newUser)[0].Name)
+	assert.Equal(t, " 42 ", args["id"], "Scalars have no tags, so should be left alone")
+	assert.Nil(t, args["none"])
+}
+
+func TestArgsStrict(t *testing.T) {
+	conform.Strict = true
+	defer func() { conform.Strict = false }()
+
+	type price struct {
+		Amount string `conform:"decimal=2"`
+	}
+	err := Args(map[string]interface{}{"input": price{Amount: "lots"}})
+	var stepErr *conform.StepError
+	assert.True(t, errors.As(err, &stepErr))
+	assert.Contains(t, err.Error(), "input: Amount: decimal=2")
+}
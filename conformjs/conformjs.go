@@ -0,0 +1,183 @@
+// Package conformjs generates a JavaScript or TypeScript module that applies a struct's simple tags in the
+// browser, so forms can preview how input will be normalised before it's submitted:
+//
+//	import { conform } from "./user.conform.js";
+//	input.value = conform("name", input.value);
+//
+// The module replicates trim, ltrim, rtrim, lower, upper, slug and maxwords=N. As tags further along a chain
+// work on the output of those before them, a field's chain is cut at the first tag the module can't replicate,
+// and fields whose first tag isn't replicated are left out.
+package conformjs
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/leebenson/conform"
+)
+
+// Tags lists the tags the module replicates
+var Tags = []string{"trim", "ltrim", "rtrim", "lower", "upper", "slug", "maxwords"}
+
+// Generate writes a JavaScript module for v's type to w. v can be anything conform.Describe accepts. Fields
+// are keyed by their JSON path, such as "address.city" or "tags[]", and those that aren't encoded are left out.
+func Generate(w io.Writer, v interface{}) error {
+	return generate(w, v, false)
+}
+
+// GenerateTS writes the module Generate does, as TypeScript
+func GenerateTS(w io.Writer, v interface{}) error {
+	return generate(w, v, true)
+}
+
+func generate(w io.Writer, v interface{}, ts bool) error {
+	plans, err := conform.Describe(v)
+	if err != nil {
+		return err
+	}
+	var rules []rule
+	for _, plan := range plans {
+		if tags := replicated(plan.Tags); plan.JSONPath != "" && len(tags) > 0 {
+			rules = append(rules, rule{Path: plan.JSONPath, Tags: tags})
+		}
+	}
+	t := template.Must(module.Clone()).Funcs(template.FuncMap{"t": func(s string) string {
+		if ts {
+			return s
+		}
+		return ""
+	}})
+	return t.Execute(w, rules)
+}
+
+// rule is a field's entry in the module
+type rule struct {
+	Path string
+	Tags []string
+}
+
+// replicated returns the tags up to the first the module can't replicate
+func replicated(tags []string) []string {
+	for i, tag := range tags {
+		if !supported(tag) {
+			return tags[:i]
+		}
+	}
+	return tags
+}
+
+func supported(tag string) bool {
+	name, param, _ := strings.Cut(tag, "=")
+	for _, t := range Tags {
+		if name == t {
+			// maxwords is the only tag the module replicates that takes a parameter
+			return (param != "") == (name == "maxwords")
+		}
+	}
+	return false
+}
+
+// initialisms are the words slug keeps whole, as conform does
+const initialisms = `"API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "LHS",
+  "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SSH", "TLS", "TTL", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM",
+  "XML"`
+
+// module is the template for the generated module. t adds TypeScript annotations.
+var module = template.Must(template.New("module").Funcs(template.FuncMap{
+	"t": func(string) string { return "" },
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}).Parse(`// Code generated by conformjs. DO NOT EDIT.
+
+// rules lists the tags applied to each field, keyed by JSON path
+export const rules{{t ": Record<string, string[]>"}} = {
+{{- range .}}
+  {{json .Path}}: {{json .Tags}},
+{{- end}}
+};
+
+const initialisms = new Set{{t "<string>"}}([
+  ` + initialisms + `,
+]);
+
+function initialism(s{{t ": string"}}){{t ": string"}} {
+  let found = "";
+  for (let i = 1; i <= 5 && i <= s.length; i++) {
+    if (initialisms.has(s.slice(0, i))) {
+      found = s.slice(0, i);
+    }
+  }
+  return found;
+}
+
+function slug(s{{t ": string"}}){{t ": string"}} {
+  const camel = (s.match(/[0-9A-Za-z]+/g) || [])
+    .map((w, i) => (i > 0 ? w[0].toUpperCase() + w.slice(1) : w))
+    .join("");
+  const words{{t ": string[]"}} = [];
+  let last = 0;
+  for (let i = 1; i < camel.length; i++) {
+    if (camel[i] >= "A" && camel[i] <= "Z") {
+      const init = initialism(camel.slice(last));
+      if (init !== "") {
+        words.push(init);
+        i += init.length - 1;
+        last = i;
+        continue;
+      }
+      words.push(camel.slice(last, i));
+      last = i;
+    }
+  }
+  if (camel.slice(last) !== "") {
+    words.push(camel.slice(last));
+  }
+  return words.map((w) => w.toLowerCase()).join("-");
+}
+
+// mapRunes maps each code point on its own, without context or expansions such as "ß" to "SS", as Go does
+function mapRunes(s{{t ": string"}}, f{{t ": (c: string) => string"}}){{t ": string"}} {
+  return Array.from(s, f).join("");
+}
+
+function maxwords(s{{t ": string"}}, max{{t ": number"}}){{t ": string"}} {
+  let words = 0;
+  let inWord = false;
+  for (let i = 0; i < s.length; i++) {
+    const space = /\s/.test(s[i]);
+    if (space && inWord) {
+      inWord = false;
+      if (words === max) {
+        return s.slice(0, i);
+      }
+    } else if (!space && !inWord) {
+      inWord = true;
+      words++;
+    }
+  }
+  return s.replace(/\s+$/, "");
+}
+
+const tags{{t ": Record<string, (s: string, param?: string) => string>"}} = {
+  trim: (s) => s.trim(),
+  ltrim: (s) => s.replace(/^ +/, ""),
+  rtrim: (s) => s.replace(/ +$/, ""),
+  lower: (s) => mapRunes(s, (c) => [...c.toLowerCase()][0]),
+  upper: (s) => mapRunes(s, (c) => ([...c.toUpperCase()].length > 1 ? c : c.toUpperCase())),
+  slug: slug,
+  maxwords: (s, n) => maxwords(s, Number(n)),
+};
+
+// conform applies the tags of the field at path to value. Values of fields without rules are returned as they are.
+export function conform(path{{t ": string"}}, value{{t ": string"}}){{t ": string"}} {
+  for (const tag of rules[path] || []) {
+    const [name, param] = tag.split("=");
+    value = tags[name](value, param);
+  }
+  return value;
+}
+`))
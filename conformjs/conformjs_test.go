@@ -0,0 +1,105 @@
+//go:build !conform_minimal
+
+package conformjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city" conform:"trim,title"`
+}
+
+type post struct {
+	Title   string   `json:"title" conform:"trim,slug"`
+	Summary string   `json:"summary" conform:"trim,maxwords=3"`
+	Author  string   `json:"author" conform:"trim,name,upper"`
+	Email   string   `json:"email" conform:"email"`
+	Tags    []string `json:"tags" conform:"lower"`
+	Address address  `json:"address"`
+	Secret  string   `json:"-" conform:"trim"`
+}
+
+func TestGenerate(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, Generate(&b, post{}))
+	out := b.String()
+
+	assert.Contains(t, out, `"title": ["trim","slug"],`)
+	assert.Contains(t, out, `"summary": ["trim","maxwords=3"],`)
+	assert.Contains(t, out, `"author": ["trim"],`, "The chain should stop at the first tag that isn't replicated")
+	assert.Contains(t, out, `"tags[]": ["lower"],`)
+	assert.Contains(t, out, `"address.city": ["trim"],`)
+	assert.NotContains(t, out, `"email"`, "Fields without replicated tags should be left out")
+	assert.NotContains(t, out, `Secret`, "Fields that aren't encoded should be left out")
+	assert.NotContains(t, out, `: string`)
+
+	assert.Error(t, Generate(&b, "not a struct"))
+}
+
+func TestGenerateTS(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, GenerateTS(&b, post{}))
+	assert.Contains(t, b.String(), "export const rules: Record<string, string[]> = {")
+	assert.Contains(t, b.String(), "export function conform(path: string, value: string): string {")
+}
+
+// TestMatchesGo runs the generated module with node, when it's installed, and checks it agrees with conform
+func TestMatchesGo(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node isn't installed")
+	}
+
+	var fields struct {
+		Trim     string `json:"trim" conform:"trim"`
+		Left     string `json:"left" conform:"ltrim"`
+		Right    string `json:"right" conform:"rtrim"`
+		Lower    string `json:"lower" conform:"lower"`
+		Upper    string `json:"upper" conform:"upper"`
+		Slug     string `json:"slug" conform:"slug"`
+		MaxWords string `json:"maxwords" conform:"maxwords=2"`
+	}
+	var b bytes.Buffer
+	assert.NoError(t, Generate(&b, fields))
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "rules.mjs"), b.Bytes(), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "run.mjs"), []byte(`
+import { conform } from "./rules.mjs";
+const { path, inputs } = JSON.parse(process.argv[2]);
+console.log(JSON.stringify(inputs.map((s) => conform(path, s))));
+`), 0o644))
+
+	inputs := []string{
+		"", "  Hello World  ", "CamelCase", "blog title here", "HTTPServer", "userID", "an APIKey for JSON",
+		"  one   two three ", "2nd place", "ÜBER straße", "İstanbul ΟΔΟΣ", "tab\tseparated\nlines ",
+	}
+	for path, tag := range map[string]string{
+		"trim": "trim", "left": "ltrim", "right": "rtrim", "lower": "lower", "upper": "upper", "slug": "slug",
+		"maxwords": "maxwords=2",
+	} {
+		arg, _ := json.Marshal(map[string]interface{}{"path": path, "inputs": inputs})
+		out, err := exec.Command(node, filepath.Join(dir, "run.mjs"), string(arg)).Output()
+		if !assert.NoError(t, err, tag) {
+			continue
+		}
+		var got []string
+		assert.NoError(t, json.Unmarshal(out, &got))
+
+		p, err := conform.Compile(tag)
+		assert.NoError(t, err)
+		for i, in := range inputs {
+			want, _ := p.Apply(in)
+			assert.Equal(t, want, got[i], "%s(%q)", tag, in)
+		}
+	}
+}
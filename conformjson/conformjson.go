@@ -0,0 +1,36 @@
+// Package conformjson decodes JSON and conforms the result in one call, so the conform pass can't be forgotten.
+// Fields of type json.RawMessage are left untouched.
+package conformjson
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/leebenson/conform"
+)
+
+// Unmarshal works like json.Unmarshal, then conforms v
+func Unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return conform.Strings(v)
+}
+
+// Decoder wraps a json.Decoder, conforming each value it decodes
+type Decoder struct {
+	*json.Decoder
+}
+
+// NewDecoder returns a Decoder that reads from r
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{json.NewDecoder(r)}
+}
+
+// Decode works like json.Decoder.Decode, then conforms v
+func (d *Decoder) Decode(v interface{}) error {
+	if err := d.Decoder.Decode(v); err != nil {
+		return err
+	}
+	return conform.Strings(v)
+}
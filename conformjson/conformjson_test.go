@@ -0,0 +1,59 @@
+//go:build !conform_minimal
+
+package conformjson
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name  string          `json:"name" conform:"trim,name"`
+	Email string          `json:"email" conform:"email"`
+	Extra json.RawMessage `json:"extra"`
+	Price string          `json:"price" conform:"decimal=2"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	var u user
+	err := Unmarshal([]byte(`{"name": " ann  o'brien ", "email": " ANN@X.IO ", "extra": {"name": " raw "}}`), &u)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ann O'Brien", u.Name)
+	assert.Equal(t, "ANN@x.io", u.Email)
+	assert.Equal(t, `{"name": " raw "}`, string(u.Extra))
+
+	assert.Error(t, Unmarshal([]byte(`{"name": 1}`), &u))
+}
+
+func TestDecoder(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`{"name": " ann "} {"name": " bob "}`))
+	d.DisallowUnknownFields()
+
+	var names []string
+	for {
+		var u user
+		if err := d.Decode(&u); err == io.EOF {
+			break
+		} else if !assert.NoError(t, err) {
+			return
+		}
+		names = append(names, u.Name)
+	}
+	assert.Equal(t, []string{"Ann", "Bob"}, names)
+}
+
+func TestDecoderStrict(t *testing.T) {
+	conform.Strict = true
+	defer func() { conform.Strict = false }()
+
+	var u user
+	err := NewDecoder(strings.NewReader(`{"price": "lots"}`)).Decode(&u)
+	var stepErr *conform.StepError
+	assert.True(t, errors.As(err, &stepErr))
+}
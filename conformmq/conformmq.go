@@ -0,0 +1,48 @@
+// Package conformmq wraps message queue consumers, so user-originated events are decoded and conformed before
+// they're handled. Handlers take the raw message body, which suits Kafka, NATS, SQS and the like:
+//
+//	handle := conformmq.Handler(func(ctx context.Context, e SignupEvent) error {
+//		// e has been decoded from JSON and conformed
+//	})
+//	err := handle(ctx, msg.Value)
+package conformmq
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/leebenson/conform"
+)
+
+// Unmarshaler decodes a message body into v, such as json.Unmarshal
+type Unmarshaler func(data []byte, v interface{}) error
+
+// Handler decodes each message body as JSON into a T, conforms it, and passes it to next
+func Handler[T any](next func(context.Context, T) error) func(context.Context, []byte) error {
+	return HandlerWith(json.Unmarshal, next)
+}
+
+// HandlerWith works like Handler, but decodes with unmarshal. When T is a pointer, such as a generated protobuf
+// message, unmarshal is given a newly allocated T, otherwise a pointer to one:
+//
+//	conformmq.HandlerWith(func(b []byte, v interface{}) error {
+//		return proto.Unmarshal(b, v.(proto.Message))
+//	}, handleSignup)
+func HandlerWith[T any](unmarshal Unmarshaler, next func(context.Context, T) error) func(context.Context, []byte) error {
+	return func(ctx context.Context, data []byte) error {
+		var v T
+		var ptr interface{} = &v
+		if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Ptr {
+			v = reflect.New(t.Elem()).Interface().(T)
+			ptr = v
+		}
+		if err := unmarshal(data, ptr); err != nil {
+			return err
+		}
+		if err := conform.StringsContext(ctx, ptr); err != nil {
+			return err
+		}
+		return next(ctx, v)
+	}
+}
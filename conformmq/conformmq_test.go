@@ -0,0 +1,67 @@
+//go:build !conform_minimal
+
+package conformmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+)
+
+type signup struct {
+	Name  string `json:"name" conform:"trim,name"`
+	Email string `json:"email" conform:"trim,email"`
+}
+
+func TestHandler(t *testing.T) {
+	var got signup
+	handle := Handler(func(ctx context.Context, s signup) error {
+		got = s
+		return nil
+	})
+
+	assert.NoError(t, handle(context.Background(), []byte(`{"name": " ann ", "email": " ANN@X.IO "}`)))
+	assert.Equal(t, signup{Name: "Ann", Email: "ANN@x.io"}, got)
+
+	assert.Error(t, handle(context.Background(), []byte(`{`)))
+}
+
+func TestHandlerPointer(t *testing.T) {
+	var got *signup
+	handle := HandlerWith(func(b []byte, v interface{}) error {
+		if _, ok := v.(*signup); !ok {
+			return errors.New("expected a *signup")
+		}
+		return json.Unmarshal(b, v)
+	}, func(ctx context.Context, s *signup) error {
+		got = s
+		return nil
+	})
+
+	assert.NoError(t, handle(context.Background(), []byte(`{"name": " bob "}`)))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "Bob", got.Name)
+	}
+}
+
+func TestHandlerStrict(t *testing.T) {
+	conform.Strict = true
+	defer func() { conform.Strict = false }()
+
+	type price struct {
+		Amount string `json:"amount" conform:"decimal=2"`
+	}
+	called := false
+	handle := Handler(func(ctx context.Context, p price) error {
+		called = true
+		return nil
+	})
+
+	var stepErr *conform.StepError
+	assert.True(t, errors.As(handle(context.Background(), []byte(`{"amount": "lots"}`)), &stepErr))
+	assert.False(t, called, "Messages that fail to conform shouldn't be handled")
+}
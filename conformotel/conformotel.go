@@ -0,0 +1,47 @@
+// Package conformotel traces conform with OpenTelemetry, recording a span for each value conformed with the name
+// of its type and how many of its strings were conformed and changed:
+//
+//	conform.SetTracer(conformotel.Tracer(otel.Tracer("github.com/leebenson/conform")))
+//
+//	err := conform.StringsContext(r.Context(), &req)
+package conformotel
+
+import (
+	"context"
+
+	"github.com/leebenson/conform"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanName is the name of the spans recorded
+const SpanName = "conform.Strings"
+
+// Attribute keys set on spans
+const (
+	TypeKey    = attribute.Key("conform.type")
+	FieldsKey  = attribute.Key("conform.fields")
+	ChangedKey = attribute.Key("conform.changed")
+)
+
+// Tracer returns a conform.Tracer that records spans with t
+func Tracer(t trace.Tracer) conform.Tracer {
+	return tracer{t}
+}
+
+type tracer struct {
+	t trace.Tracer
+}
+
+func (t tracer) Start(ctx context.Context, typeName string) func(fields, changed int, err error) {
+	_, span := t.t.Start(ctx, SpanName, trace.WithAttributes(TypeKey.String(typeName)))
+	return func(fields, changed int, err error) {
+		span.SetAttributes(FieldsKey.Int(fields), ChangedKey.Int(changed))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,46 @@
+package conformotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leebenson/conform"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type signup struct {
+	Name  string `conform:"trim,name"`
+	Email string `conform:"trim,email"`
+}
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	conform.SetTracer(Tracer(provider.Tracer("test")))
+	defer conform.SetTracer(nil)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	s := signup{Name: " ann ", Email: "ann@x.io"}
+	assert.NoError(t, conform.StringsContext(ctx, &s))
+	parent.End()
+	assert.Error(t, conform.Strings(s))
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+	span := spans[0]
+	assert.Equal(t, SpanName, span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		TypeKey.String("*conformotel.signup"), FieldsKey.Int(2), ChangedKey.Int(1),
+	}, span.Attributes())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Len(t, spans[2].Events(), 1, "The error should be recorded")
+}
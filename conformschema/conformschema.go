@@ -0,0 +1,103 @@
+// Package conformschema annotates JSON Schemas and OpenAPI documents with the tags conform applies to each
+// field, as an "x-conform" extension listing them in order, so clients know how their input will be normalised:
+//
+//	"name": {"type": "string", "x-conform": ["trim", "name"]}
+//
+// Schemas are worked on as decoded JSON, so any generator, or a hand-written document, can be annotated.
+package conformschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leebenson/conform"
+)
+
+// Extension is the key the tags are added under
+const Extension = "x-conform"
+
+// Annotate adds the tags of v's type to schema, a JSON Schema for it. Properties are matched by the names in
+// `json` tags, and "$ref"s are followed within schema. Fields the schema doesn't have are skipped.
+func Annotate(schema map[string]interface{}, v interface{}) error {
+	return annotate(schema, schema, v)
+}
+
+// AnnotateOpenAPI adds the tags of the types in schemas to the OpenAPI document doc, keyed by their names
+// under "components/schemas", or "definitions" for Swagger 2.0. "$ref"s are followed within doc.
+func AnnotateOpenAPI(doc map[string]interface{}, schemas map[string]interface{}) error {
+	defs, _ := object(doc["components"])["schemas"].(map[string]interface{})
+	if defs == nil {
+		defs, _ = doc["definitions"].(map[string]interface{})
+	}
+	for name, v := range schemas {
+		schema, ok := defs[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("no schema named %q", name)
+		}
+		if err := annotate(doc, schema, v); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func annotate(root, schema map[string]interface{}, v interface{}) error {
+	plans, err := conform.Describe(v)
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if plan.JSONPath == "" {
+			continue
+		}
+		if node := find(root, schema, plan.JSONPath); node != nil {
+			node[Extension] = plan.Tags
+		}
+	}
+	return nil
+}
+
+// find follows a JSON path, such as "address.city" or "tags[]", down from schema, returning nil if the schema
+// doesn't have it
+func find(root, schema map[string]interface{}, path string) map[string]interface{} {
+	node := resolve(root, schema)
+	for _, name := range strings.Split(path, ".") {
+		elements := strings.HasSuffix(name, "[]")
+		node = resolve(root, object(object(node["properties"])[strings.TrimSuffix(name, "[]")]))
+		if elements {
+			items := object(node["items"])
+			if items == nil {
+				items = object(node["additionalProperties"])
+			}
+			node = resolve(root, items)
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// resolve follows a local "$ref", such as "#/components/schemas/Address", within root
+func resolve(root, node map[string]interface{}) map[string]interface{} {
+	for i := 0; node != nil && i < 32; i++ {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+		node = root
+		for _, key := range strings.Split(ref[2:], "/") {
+			key = strings.NewReplacer("~1", "/", "~0", "~").Replace(key)
+			node = object(node[key])
+		}
+	}
+	return node
+}
+
+func object(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
@@ -0,0 +1,92 @@
+//go:build !conform_minimal
+
+package conformschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city" conform:"trim,title"`
+}
+
+type user struct {
+	Name    string            `json:"name" conform:"trim,name"`
+	Email   string            `json:"email" conform:"email"`
+	Tags    []string          `json:"tags" conform:"lower"`
+	Address address           `json:"address"`
+	Labels  map[string]string `json:"labels" conform:"upper"`
+	Missing string            `json:"missing" conform:"trim"`
+}
+
+func decode(t *testing.T, s string) map[string]interface{} {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func extension(node interface{}) interface{} {
+	return node.(map[string]interface{})[Extension]
+}
+
+func TestAnnotate(t *testing.T) {
+	schema := decode(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"email": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"address": {"$ref": "#/$defs/address"},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+		},
+		"$defs": {
+			"address": {"type": "object", "properties": {"city": {"type": "string"}}}
+		}
+	}`)
+
+	assert.NoError(t, Annotate(schema, user{}))
+
+	props := schema["properties"].(map[string]interface{})
+	assert.Equal(t, []string{"trim", "name"}, extension(props["name"]))
+	assert.Equal(t, []string{"email"}, extension(props["email"]))
+	assert.Equal(t, []string{"lower"}, extension(props["tags"].(map[string]interface{})["items"]))
+	assert.Equal(t, []string{"upper"}, extension(props["labels"].(map[string]interface{})["additionalProperties"]))
+	city := schema["$defs"].(map[string]interface{})["address"].(map[string]interface{})["properties"].(map[string]interface{})["city"]
+	assert.Equal(t, []string{"trim", "title"}, extension(city), "$refs should be followed")
+
+	_, err := json.Marshal(schema)
+	assert.NoError(t, err)
+}
+
+func TestAnnotateOpenAPI(t *testing.T) {
+	doc := decode(t, `{
+		"openapi": "3.0.3",
+		"components": {
+			"schemas": {
+				"User": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"address": {"$ref": "#/components/schemas/Address"}
+					}
+				},
+				"Address": {"type": "object", "properties": {"city": {"type": "string"}}}
+			}
+		}
+	}`)
+
+	assert.NoError(t, AnnotateOpenAPI(doc, map[string]interface{}{"User": user{}}))
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	name := schemas["User"].(map[string]interface{})["properties"].(map[string]interface{})["name"]
+	assert.Equal(t, []string{"trim", "name"}, extension(name))
+	city := schemas["Address"].(map[string]interface{})["properties"].(map[string]interface{})["city"]
+	assert.Equal(t, []string{"trim", "title"}, extension(city))
+
+	assert.EqualError(t, AnnotateOpenAPI(doc, map[string]interface{}{"Order": user{}}), `no schema named "Order"`)
+}
@@ -0,0 +1,129 @@
+// Package conformtest property-tests sanitizers, so custom ones added with conform.AddSanitizer can be shown
+// to meet the assumptions the engine makes about them:
+//
+//	func TestShout(t *testing.T) {
+//		conformtest.Check(t, shout, conformtest.Idempotent, conformtest.PreservesUTF8, conformtest.NoLonger)
+//	}
+package conformtest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/leebenson/conform"
+)
+
+// Property checks a sanitizer's output for one input, returning why it doesn't hold
+type Property func(f func(string) string, in, out string) error
+
+var (
+	// Idempotent holds when sanitizing the output again changes nothing, so conforming a value twice is safe
+	Idempotent Property = func(f func(string) string, in, out string) error {
+		if again := f(out); again != out {
+			return fmt.Errorf("not idempotent, sanitizing again gives %q", again)
+		}
+		return nil
+	}
+
+	// PreservesUTF8 holds when valid UTF-8 input gives valid UTF-8 output
+	PreservesUTF8 Property = func(f func(string) string, in, out string) error {
+		if utf8.ValidString(in) && !utf8.ValidString(out) {
+			return fmt.Errorf("not valid UTF-8")
+		}
+		return nil
+	}
+
+	// NoLonger holds when the output has no more runes than the input
+	NoLonger Property = func(f func(string) string, in, out string) error {
+		if n, m := utf8.RuneCountInString(out), utf8.RuneCountInString(in); n > m {
+			return fmt.Errorf("%d runes is longer than the input's %d", n, m)
+		}
+		return nil
+	}
+)
+
+// MaxLength holds when the output has at most n runes
+func MaxLength(n int) Property {
+	return func(f func(string) string, in, out string) error {
+		if m := utf8.RuneCountInString(out); m > n {
+			return fmt.Errorf("%d runes is longer than %d", m, n)
+		}
+		return nil
+	}
+}
+
+// Corpus holds awkward inputs every check is run on, before the random ones
+var Corpus = []string{
+	"",
+	" ",
+	"\t\n\r ",
+	"a",
+	"Hello, World!",
+	"  mixed   CASE  text  ",
+	"ÀÉÎÕÜ àéîõü ß",
+	"é combining",
+	"İstanbul ΣΊΣΥΦΟΣ",
+	"日本語のテキスト",
+	"👩‍👩‍👧 family 🇬🇧",
+	"مرحبا بالعالم",
+	"\u200b\u200d\ufeff zero width",
+	"<b>bold</b> & \"quotes\" 'single'",
+	"1,234.56",
+	"user@Example.COM",
+	"\xff\xfe invalid",
+}
+
+// Inputs returns n random strings from seed, mixing ASCII, whitespace, punctuation, accented letters, other
+// scripts and emoji. The same seed always gives the same strings.
+func Inputs(n int, seed int64) []string {
+	pools := []string{
+		"abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		"0123456789",
+		" \t\n",
+		"!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~",
+		"àáâãäåæçèéêëìíîïñòóôõöøùúûüýÿßÀÉÎÕÜ",
+		"αβγδεΣΩжщыЯ",
+		"日本語中文한국어",
+		"😀👍🏽🇬🇧\u200d\u0301",
+	}
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]string, n)
+	for i := range inputs {
+		var b strings.Builder
+		for j := r.Intn(40); j > 0; j-- {
+			pool := []rune(pools[r.Intn(len(pools))])
+			b.WriteRune(pool[r.Intn(len(pool))])
+		}
+		inputs[i] = b.String()
+	}
+	return inputs
+}
+
+// Check runs f on the Corpus and 500 random Inputs, reporting each input a property doesn't hold for
+func Check(t testing.TB, f func(string) string, props ...Property) {
+	t.Helper()
+	for _, in := range append(append([]string(nil), Corpus...), Inputs(500, 1)...) {
+		out := f(in)
+		for _, prop := range props {
+			if err := prop(f, in, out); err != nil {
+				t.Errorf("%q gives %q: %v", in, out, err)
+			}
+		}
+	}
+}
+
+// CheckTags works like Check, for a chain of tags such as "trim,lower"
+func CheckTags(t testing.TB, tags string, props ...Property) {
+	t.Helper()
+	p, err := conform.Compile(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Check(t, func(s string) string {
+		out, _ := p.Apply(s)
+		return out
+	}, props...)
+}
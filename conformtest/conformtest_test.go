@@ -0,0 +1,51 @@
+//go:build !conform_minimal
+
+package conformtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTags(t *testing.T) {
+	for _, tags := range []string{"trim", "lower", "upper", "name", "alpha", "num", "nomarkdown"} {
+		t.Run(tags, func(t *testing.T) {
+			CheckTags(t, tags, Idempotent, PreservesUTF8)
+		})
+	}
+	CheckTags(t, "trim,excerpt=10", Idempotent, PreservesUTF8, MaxLength(10))
+}
+
+func TestProperties(t *testing.T) {
+	double := func(s string) string { return s + s }
+	assert.Error(t, Idempotent(double, "a", "aa"))
+	assert.NoError(t, Idempotent(strings.TrimSpace, " a ", "a"))
+	assert.Error(t, NoLonger(double, "a", "aa"))
+	assert.Error(t, MaxLength(1)(double, "a", "aa"))
+	assert.Error(t, PreservesUTF8(nil, "é", "\xc3"))
+	assert.NoError(t, PreservesUTF8(nil, "\xff", "\xff"), "Invalid input gives no guarantees")
+}
+
+func TestInputs(t *testing.T) {
+	assert.Equal(t, Inputs(10, 7), Inputs(10, 7), "The same seed should give the same inputs")
+	assert.Len(t, Inputs(10, 7), 10)
+}
+
+type recorder struct {
+	testing.TB
+	errors int
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors++
+}
+
+func TestCheckReports(t *testing.T) {
+	r := &recorder{TB: t}
+	Check(r, func(s string) string { return s + "!" }, Idempotent)
+	assert.Equal(t, len(Corpus)+500, r.errors)
+}
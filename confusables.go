@@ -0,0 +1,33 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusableLatin maps letters from other scripts that look like Latin ones, taken from the Unicode TR39
+// confusables data. Fullwidth, mathematical and other compatibility forms are folded by NFKC first.
+var confusableLatin = strings.NewReplacer(
+	// Cyrillic
+	"а", "a", "е", "e", "о", "o", "р", "p", "с", "c", "у", "y", "х", "x", "ѕ", "s", "і", "i", "ј", "j",
+	"ԁ", "d", "ԛ", "q", "ԝ", "w", "һ", "h", "ӏ", "l", "ү", "y", "ɡ", "g",
+	"А", "A", "В", "B", "Е", "E", "К", "K", "М", "M", "Н", "H", "О", "O", "Р", "P", "С", "C", "Т", "T", "Х", "X",
+	"У", "Y", "Ѕ", "S", "І", "I", "Ј", "J", "Ԛ", "Q", "Ԝ", "W", "Ү", "Y", "Ӏ", "I",
+	// Greek
+	"α", "a", "ο", "o", "ν", "v", "ρ", "p", "υ", "u", "ι", "i", "ϲ", "c", "ϳ", "j",
+	"Α", "A", "Β", "B", "Ε", "E", "Ζ", "Z", "Η", "H", "Ι", "I", "Κ", "K", "Μ", "M", "Ν", "N", "Ο", "O", "Ρ", "P",
+	"Τ", "T", "Υ", "Y", "Χ", "X", "Ϲ", "C",
+	// Armenian
+	"օ", "o", "ս", "u", "ո", "n", "հ", "h", "Օ", "O", "Տ", "S",
+	// Latin lookalikes
+	"ı", "i", "ɑ", "a", "ǀ", "l", "ɩ", "i", "ꞵ", "B",
+)
+
+// confusables replaces characters that are easily mistaken for Latin letters and digits with them, so spoofed
+// usernames and domains compare equal to the ones they imitate
+func confusables(s string) string {
+	return norm.NFC.String(confusableLatin.Replace(norm.NFKC.String(s)))
+}
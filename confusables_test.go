@@ -0,0 +1,28 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestConfusables() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"раураl.com":  "paypal.com",
+		"аррӏе":       "apple",
+		"ＧＯＯＧＬＥ１２３":   "GOOGLE123",
+		"𝐚𝐝𝐦𝐢𝐧":       "admin",
+		"ΑΡΡLΕ":       "APPLE",
+		"ﬁle":         "file",
+		"plain ascii": "plain ascii",
+		"café":        "café",
+		"ѕсаm":        "scam",
+		"日本語":         "日本語",
+		"éclair":     "éclair",
+		"ı":           "i",
+	} {
+		assert.Equal(want, confusables(in), in)
+	}
+}
@@ -0,0 +1,84 @@
+package conform
+
+import "reflect"
+
+// Copy deep-copies src and conforms the copy, leaving src untouched, for when the raw input must be kept, such as
+// for auditing. src can be a struct or a pointer to one.
+func Copy[T any](src T) (T, error) {
+	dst := deepCopy(reflect.ValueOf(&src).Elem(), map[ptrKey]reflect.Value{}).Interface().(T)
+	if v := reflect.ValueOf(dst); v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return dst, nil
+		}
+		return dst, Strings(dst)
+	}
+	return dst, Strings(&dst)
+}
+
+// ptrKey identifies a pointer by its address and type, as a pointer to a struct and a pointer to its first field
+// share an address
+type ptrKey struct {
+	addr uintptr
+	t    reflect.Type
+}
+
+// deepCopy copies pointers, slices, maps and interfaces all the way down. seen maps pointers already copied to
+// their copies, so cycles and shared pointers are kept. Unexported fields are copied as they are.
+func deepCopy(v reflect.Value, seen map[ptrKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := ptrKey{v.Pointer(), v.Type()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[key] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < c.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+	}
+	return v
+}
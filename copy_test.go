@@ -0,0 +1,100 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestCopy() {
+	assert := assert.New(t.T())
+
+	type Address struct {
+		City string `conform:"trim,title"`
+	}
+	type Form struct {
+		Name    string            `conform:"trim,name"`
+		Nick    *string           `conform:"trim"`
+		Tags    []string          `conform:"lower"`
+		Labels  map[string]string `conform:"upper"`
+		Address Address
+		Homes   []*Address
+		Extra   interface{}
+		Self    *Form
+	}
+
+	nick := " al "
+	src := Form{
+		Name:    " ann ",
+		Nick:    &nick,
+		Tags:    []string{"A"},
+		Labels:  map[string]string{"k": "v"},
+		Address: Address{City: " paris "},
+		Homes:   []*Address{{City: " rome "}},
+		Extra:   []string{"x"},
+	}
+
+	dst, err := Copy(src)
+	assert.NoError(err)
+	assert.Equal("Ann", dst.Name)
+	assert.Equal("al", *dst.Nick)
+	assert.Equal([]string{"a"}, dst.Tags)
+	assert.Equal(map[string]string{"k": "V"}, dst.Labels)
+	assert.Equal("Paris", dst.Address.City)
+	assert.Equal("Rome", dst.Homes[0].City)
+	assert.Equal([]string{"x"}, dst.Extra)
+
+	assert.Equal(" ann ", src.Name, "The source should be left alone")
+	assert.Equal(" al ", *src.Nick)
+	assert.Equal([]string{"A"}, src.Tags)
+	assert.Equal(map[string]string{"k": "v"}, src.Labels)
+	assert.Equal(" rome ", src.Homes[0].City)
+
+	// pointers
+	p := &Form{Name: " bob "}
+	q, err := Copy(p)
+	assert.NoError(err)
+	assert.Equal("Bob", q.Name)
+	assert.Equal(" bob ", p.Name)
+
+	// cycles should point at the copy
+	p.Self = p
+	c := deepCopy(reflect.ValueOf(p), map[ptrKey]reflect.Value{}).Interface().(*Form)
+	assert.True(c != p && c.Self == c)
+
+	// a pointer to a struct shares its address with a pointer to its first field
+	type Inner struct {
+		Name string `conform:"trim"`
+	}
+	type Outer struct {
+		Inner *Inner
+		Name  *string
+	}
+	inner := &Inner{Name: " ann "}
+	o, err := Copy(Outer{Inner: inner, Name: &inner.Name})
+	assert.NoError(err)
+	assert.Equal("ann", o.Inner.Name)
+	assert.Equal(" ann ", inner.Name)
+
+	var nilForm *Form
+	q, err = Copy(nilForm)
+	assert.NoError(err)
+	assert.Nil(q)
+}
+
+func (t *testSuite) TestCopyStrict() {
+	assert := assert.New(t.T())
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	type Price struct {
+		Amount string `conform:"decimal=2"`
+	}
+	_, err := Copy(Price{Amount: "lots"})
+	var stepErr *StepError
+	assert.True(errors.As(err, &stepErr))
+}
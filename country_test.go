@@ -0,0 +1,53 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestCountry() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Name    string `conform:"country"`
+		Alpha2  string `conform:"country"`
+		Alpha3  string `conform:"country"`
+		Alias   string `conform:"country"`
+		Dotted  string `conform:"country"`
+		Unknown string `conform:"country"`
+	}
+
+	s.Name = "  united   kingdom "
+	s.Alpha2 = "fr"
+	s.Alpha3 = "DEU"
+	s.Alias = "UK"
+	s.Dotted = "U.S.A."
+	s.Unknown = "Atlantis"
+	Strings(&s)
+
+	assert.Equal("GB", s.Name)
+	assert.Equal("FR", s.Alpha2)
+	assert.Equal("DE", s.Alpha3)
+	assert.Equal("GB", s.Alias)
+	assert.Equal("US", s.Dotted)
+	assert.Equal("Atlantis", s.Unknown, "Unknown countries should be left alone")
+
+	RegisterCountryAlias("Blighty", "gb")
+	s.Alias = "blighty"
+	Strings(&s)
+	assert.Equal("GB", s.Alias, "Registered aliases should be used")
+
+	// registering while countries are being conformed is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterCountryAlias("Old Blighty", "gb")
+	}()
+	country("old blighty")
+	wg.Wait()
+	assert.Equal("GB", country("old blighty"))
+}
@@ -0,0 +1,92 @@
+package conform
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// CSVRecordReader wraps a csv.Reader, conforming each record's columns as they're read
+type CSVRecordReader struct {
+	r       *csv.Reader
+	columns []csvColumn
+	header  []string
+	// byName holds the rules until the header has been read
+	byName Rules
+}
+
+type csvColumn struct {
+	index int
+	tags  string
+}
+
+// CSVReader conforms each record's columns using rules keyed by column index, starting at 0
+func CSVReader(r *csv.Reader, rules map[int]string) *CSVRecordReader {
+	c := &CSVRecordReader{r: r}
+	for i, tags := range rules {
+		c.columns = append(c.columns, csvColumn{index: i, tags: tags})
+	}
+	sort.Slice(c.columns, func(i, j int) bool { return c.columns[i].index < c.columns[j].index })
+	return c
+}
+
+// CSVReaderByHeader conforms each record's columns using rules keyed by header name. The first record is
+// taken as the header and returned unchanged.
+func CSVReaderByHeader(r *csv.Reader, rules Rules) *CSVRecordReader {
+	return &CSVRecordReader{r: r, byName: rules}
+}
+
+// Read reads and conforms one record. If Strict is set and a tag can't be applied, the record is returned
+// along with an error naming the line and column.
+func (c *CSVRecordReader) Read() ([]string, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		return record, err
+	}
+
+	if c.byName != nil {
+		c.header = append([]string(nil), record...)
+		for i, name := range record {
+			if tags, ok := c.byName[name]; ok {
+				c.columns = append(c.columns, csvColumn{index: i, tags: tags})
+			}
+		}
+		c.byName = nil
+		return record, nil
+	}
+
+	for _, col := range c.columns {
+		if col.index >= len(record) {
+			break
+		}
+		out, err := compiledPipeline(col.tags).apply(record[col.index], Strict)
+		if err != nil {
+			line, _ := c.r.FieldPos(col.index)
+			return record, fmt.Errorf("line %d: %s: %w", line, c.columnName(col.index), err)
+		}
+		record[col.index] = out
+	}
+	return record, nil
+}
+
+// ReadAll reads and conforms the remaining records
+func (c *CSVRecordReader) ReadAll() ([][]string, error) {
+	var records [][]string
+	for {
+		record, err := c.Read()
+		if err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+func (c *CSVRecordReader) columnName(i int) string {
+	if i < len(c.header) {
+		return c.header[i]
+	}
+	return fmt.Sprintf("column %d", i+1)
+}
@@ -0,0 +1,47 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"encoding/csv"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestCSVReader() {
+	assert := assert.New(t.T())
+
+	in := "  Ann ,ANN@X.IO,1\n bob ,Bob@X.io\n"
+	cr := csv.NewReader(strings.NewReader(in))
+	cr.FieldsPerRecord = -1
+	r := CSVReader(cr, map[int]string{0: "trim,name", 1: "email", 5: "upper"})
+
+	records, err := r.ReadAll()
+	assert.NoError(err)
+	assert.Equal([][]string{
+		{"Ann", "ANN@x.io", "1"},
+		{"Bob", "Bob@x.io"},
+	}, records)
+}
+
+func (t *testSuite) TestCSVReaderByHeader() {
+	assert := assert.New(t.T())
+
+	in := "sku,price\nA1,\"1,250.5\"\nB2,lots\n"
+	r := CSVReaderByHeader(csv.NewReader(strings.NewReader(in)), Rules{"price": "thousands_strip,decimal=2"})
+
+	records, err := r.ReadAll()
+	assert.NoError(err)
+	assert.Equal([][]string{
+		{"sku", "price"},
+		{"A1", "1250.50"},
+		{"B2", "lots"},
+	}, records)
+
+	Strict = true
+	defer func() { Strict = false }()
+	r = CSVReaderByHeader(csv.NewReader(strings.NewReader(in)), Rules{"price": "thousands_strip,decimal=2"})
+	_, err = r.ReadAll()
+	assert.EqualError(err, `line 3: price: decimal=2: "lots" is not a decimal number`)
+}
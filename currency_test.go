@@ -0,0 +1,46 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestCurrency() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Name    string `conform:"currency"`
+		Code    string `conform:"currency"`
+		Symbol  string `conform:"currency"`
+		Unknown string `conform:"currency"`
+	}
+
+	s.Name = "US Dollar"
+	s.Code = " gbp"
+	s.Symbol = "€"
+	s.Unknown = "Galleon"
+	Strings(&s)
+
+	assert.Equal("USD", s.Name)
+	assert.Equal("GBP", s.Code)
+	assert.Equal("EUR", s.Symbol)
+	assert.Equal("Galleon", s.Unknown, "Unknown currencies should be left alone")
+
+	RegisterCurrencyAlias("Galleon", "xxx")
+	Strings(&s)
+	assert.Equal("XXX", s.Unknown, "Registered aliases should be used")
+
+	// registering while currencies are being conformed is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterCurrencyAlias("Sickle", "xxx")
+	}()
+	currency("sickle")
+	wg.Wait()
+	assert.Equal("XXX", currency("sickle"))
+}
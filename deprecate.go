@@ -0,0 +1,57 @@
+package conform
+
+import (
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// deprecatedTags maps the old names of renamed tags to their new ones
+var deprecatedTags = struct {
+	sync.RWMutex
+	m map[string]string
+	// warned holds the old names a warning has been logged for
+	warned map[string]bool
+}{m: map[string]string{}, warned: map[string]bool{}}
+
+// tagRenames counts the calls to DeprecateTag, so registries can tell when the pipelines they've cached are stale
+var tagRenames uint64
+
+// DeprecateTag registers old as the name a tag had before it was renamed to name, so fields tagged with old keep
+// working, parameters and all, while they're moved over. The first time old is used, a warning is logged with the
+// logger set by WithLogger, or slog's default logger if there isn't one. Chains of tags already compiled are
+// compiled again, so they pick up the new name.
+func DeprecateTag(old, name string) {
+	deprecatedTags.Lock()
+	deprecatedTags.m[old] = name
+	deprecatedTags.Unlock()
+	atomic.AddUint64(&tagRenames, 1)
+}
+
+// renameDeprecated returns tag under its new name, if it's been renamed, warning once for each old name used
+func renameDeprecated(tag string) string {
+	old, param := splitTag(tag)
+	deprecatedTags.RLock()
+	name, ok := deprecatedTags.m[old]
+	deprecatedTags.RUnlock()
+	if !ok {
+		return tag
+	}
+
+	deprecatedTags.Lock()
+	warned := deprecatedTags.warned[old]
+	deprecatedTags.warned[old] = true
+	deprecatedTags.Unlock()
+	if !warned {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		l.Warn("conform: deprecated tag", "tag", old, "use", name)
+	}
+
+	if len(old) < len(tag) {
+		return name + "=" + param
+	}
+	return name
+}
@@ -0,0 +1,63 @@
+package conform
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestDeprecateTag() {
+	assert := assert.New(t.T())
+
+	var b bytes.Buffer
+	WithLogger(slog.New(slog.NewTextHandler(&b, nil)))
+	defer WithLogger(nil)
+
+	var early struct {
+		Code string `conform:"trim,shout"`
+	}
+	early.Code = " gb "
+	assert.NoError(Strings(&early))
+	assert.Equal("gb", early.Code)
+
+	DeprecateTag("shout", "upper")
+	DeprecateTag("words", "maxwords")
+	defer func() {
+		deprecatedTags.Lock()
+		delete(deprecatedTags.m, "shout")
+		delete(deprecatedTags.m, "words")
+		delete(deprecatedTags.warned, "shout")
+		delete(deprecatedTags.warned, "words")
+		deprecatedTags.Unlock()
+	}()
+
+	var s struct {
+		Name    string `conform:"trim,shout"`
+		Code    string `conform:"shout"`
+		Summary string `conform:"words=2"`
+	}
+	s.Name, s.Code, s.Summary = " ann ", "gb", "one two three"
+	assert.NoError(Strings(&s))
+	assert.Equal("ANN", s.Name, "Deprecated tags should keep working")
+	assert.Equal("GB", s.Code)
+	assert.Equal("one two", s.Summary, "Parameters should be kept")
+
+	out := b.String()
+	assert.Contains(out, `level=WARN msg="conform: deprecated tag" tag=shout use=upper`)
+	assert.Contains(out, "tag=words use=maxwords")
+	assert.Equal(1, strings.Count(out, "tag=shout"), "Each deprecated tag should only be warned about once")
+
+	p, err := Compile("shout")
+	assert.NoError(err)
+	assert.Equal("upper", p.String())
+
+	p, err = Compile("trim|shout,words=1|lower")
+	assert.NoError(err)
+	assert.Equal("trim|upper,maxwords=1|lower", p.String(), "Each tag a|b tries should be renamed")
+
+	early.Code = " gb "
+	assert.NoError(Strings(&early))
+	assert.Equal("GB", early.Code, "Chains compiled before a tag was deprecated should pick up its new name")
+}
@@ -0,0 +1,125 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldPlan describes how a field is conformed
+type FieldPlan struct {
+	// Path locates the field, e.g. "Name" or "Address.City". Elements of slices and maps are written "[]", as in
+	// "Tags[]" or "Items[].SKU".
+	Path string
+	// JSONPath locates the field by the names in its `json` tags, as it appears in JSON and schemas for it, e.g.
+	// "address.city". Fields that aren't encoded, such as those tagged `json:"-"`, have none.
+	JSONPath string
+	// Tags is the chain of tags applied to the field, in order, with aliases such as "secure" expanded
+	Tags []string
+}
+
+// Describe lists the fields of v's type that have tags, and the chain of tags each is conformed with, in the
+// order Strings conforms them. v can be a struct, a pointer to one or a nil pointer of that type, such as
+// (*User)(nil). A tag that can't be compiled returns an error naming its field.
+func Describe(v interface{}) ([]FieldPlan, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", ErrUnsupportedKind, t)
+	}
+	var plans []FieldPlan
+	w := newWalker(Options{})
+	if err := w.describeStruct(t, planPath{}, map[reflect.Type]bool{}, &plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// planPath locates a field as Go and JSON see it. Once a field isn't encoded, neither is anything in it.
+type planPath struct {
+	path, json string
+	noJSON     bool
+}
+
+// field adds f to the path. Like encoding/json, embedded structs without a name in their `json` tag add
+// nothing to the JSON path, so their fields appear in the struct embedding them.
+func (p planPath) field(f reflect.StructField) planPath {
+	p.path = join(p.path, f.Name)
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch {
+	case name == "-" || !f.IsExported() && !f.Anonymous:
+		p.noJSON = true
+	case name == "" && f.Anonymous:
+	case name == "":
+		p.json = join(p.json, f.Name)
+	default:
+		p.json = join(p.json, name)
+	}
+	return p
+}
+
+// elements marks the path as the elements of a slice or map
+func (p planPath) elements() planPath {
+	p.path += "[]"
+	p.json += "[]"
+	return p
+}
+
+// describeStruct adds the plans for t's fields. Types already being described are skipped, so recursive types
+// end.
+func (w walker) describeStruct(t reflect.Type, at planPath, seen map[reflect.Type]bool, plans *[]FieldPlan) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+	w, err := w.versioned(t)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range w.fieldOrder(t) {
+		f := t.Field(i)
+		fieldAt := at.field(f)
+		ft := f.Type
+		if w.holdsStructs(f) {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() != reflect.Struct {
+				fieldAt = fieldAt.elements()
+				ft = ft.Elem()
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+			}
+			if err := w.describeStruct(ft, fieldAt, seen, plans); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tags := w.tags(f)
+		if tags == "" || !f.IsExported() {
+			continue
+		}
+		if ft.Kind() == reflect.Slice || ft.Kind() == reflect.Map {
+			fieldAt = fieldAt.elements()
+		}
+		p, err := w.opts.Registry.compile(tags, w.opts.Locale)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fieldAt.path, err)
+		}
+		plan := FieldPlan{Path: fieldAt.path}
+		if !fieldAt.noJSON {
+			plan.JSONPath = fieldAt.json
+		}
+		for _, s := range p.steps {
+			plan.Tags = append(plan.Tags, s.tag)
+		}
+		*plans = append(*plans, plan)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type describedAddress struct {
+	City string `json:"city" conform:"trim,title"`
+	Zip  string `json:"zip,omitempty" conform:"postal=US"`
+}
+
+type describedNode struct {
+	Label string `conform:"lower"`
+	Next  *describedNode
+}
+
+type describedMeta struct {
+	Source string `json:"source" conform:"lower"`
+}
+
+type describedUser struct {
+	describedMeta
+	Name     string `json:"name" conform:"name"`
+	Secret   string `json:"-" conform:"trim"`
+	Bio      string `conform:"secure,maxwords=50"`
+	Age      int
+	Plain    string
+	Address  describedAddress
+	Previous []*describedAddress
+	Tags     []string          `conform:"dive,hashtags"`
+	Labels   map[string]string `conform:"upper"`
+	Node     describedNode
+}
+
+func (t *testSuite) TestDescribe() {
+	assert := assert.New(t.T())
+
+	want := []FieldPlan{
+		{Path: "describedMeta.Source", JSONPath: "source", Tags: []string{"lower"}},
+		{Path: "Name", JSONPath: "name", Tags: []string{"name"}},
+		{Path: "Secret", Tags: []string{"trim"}},
+		{Path: "Bio", JSONPath: "Bio", Tags: []string{"validutf8", "nocontrol", "nobidi", "trim", "maxwords=50"}},
+		{Path: "Address.City", JSONPath: "Address.city", Tags: []string{"trim", "title"}},
+		{Path: "Address.Zip", JSONPath: "Address.zip", Tags: []string{"postal=US"}},
+		{Path: "Previous[].City", JSONPath: "Previous[].city", Tags: []string{"trim", "title"}},
+		{Path: "Previous[].Zip", JSONPath: "Previous[].zip", Tags: []string{"postal=US"}},
+		{Path: "Tags[]", JSONPath: "Tags[]", Tags: []string{"hashtags"}},
+		{Path: "Labels[]", JSONPath: "Labels[]", Tags: []string{"upper"}},
+		{Path: "Node.Label", JSONPath: "Node.Label", Tags: []string{"lower"}},
+	}
+
+	plans, err := Describe(describedUser{})
+	assert.NoError(err)
+	assert.Equal(want, plans)
+
+	plans, err = Describe((*describedUser)(nil))
+	assert.NoError(err)
+	assert.Equal(want, plans, "A nil pointer should describe its type")
+
+	_, err = Describe("nope")
+	assert.EqualError(err, "unsupported kind: string is not a struct")
+
+	var bad struct {
+		Price string `conform:"trim,decimal=x"`
+	}
+	_, err = Describe(bad)
+	assert.EqualError(err, `Price: decimal=x: "x" is not a whole number of at least 0`)
+}
@@ -0,0 +1,148 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldChange is a string that differs between two values of the same type
+type FieldChange struct {
+	// Path locates the string, e.g. "Name", "Address.City", "Tags[0]" or "Labels[home]"
+	Path   string
+	Before string
+	After  string
+}
+
+// Diff lists the strings that differ between before and after, which should be of the same type, such as a value
+// and its conformed Copy. Structs, pointers, slices, arrays and maps are compared all the way down, with map keys
+// in sorted order. Values of different types have no comparable strings, so return nil.
+func Diff(before, after interface{}) []FieldChange {
+	b, a := reflect.ValueOf(before), reflect.ValueOf(after)
+	if !b.IsValid() || !a.IsValid() || b.Type() != a.Type() {
+		return nil
+	}
+	var changes []FieldChange
+	diffValues("", b, a, &changes, map[diffPair]bool{})
+	return changes
+}
+
+// diffPair is a pair of pointers or maps being compared. Each pair is compared once, so cycles end.
+type diffPair struct {
+	b, a ptrKey
+}
+
+func diffValues(path string, b, a reflect.Value, changes *[]FieldChange, seen map[diffPair]bool) {
+	if (b.Kind() == reflect.Ptr || b.Kind() == reflect.Map) && !b.IsNil() && !a.IsNil() {
+		pair := diffPair{ptrKey{b.Pointer(), b.Type()}, ptrKey{a.Pointer(), a.Type()}}
+		if seen[pair] {
+			return
+		}
+		seen[pair] = true
+	}
+	switch b.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if b.IsNil() || a.IsNil() {
+			if b.IsNil() != a.IsNil() {
+				diffValues(path, zeroIfNil(b), zeroIfNil(a), changes, seen)
+			}
+			return
+		}
+		if b.Elem().Type() == a.Elem().Type() {
+			diffValues(path, b.Elem(), a.Elem(), changes, seen)
+		}
+	case reflect.Struct:
+		for i := 0; i < b.NumField(); i++ {
+			if f := b.Type().Field(i); f.IsExported() {
+				diffValues(join(path, f.Name), b.Field(i), a.Field(i), changes, seen)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		n := b.Len()
+		if a.Len() > n {
+			n = a.Len()
+		}
+		for i := 0; i < n; i++ {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), index(b, i), index(a, i), changes, seen)
+		}
+	case reflect.Map:
+		found := map[interface{}]bool{}
+		var keys []reflect.Value
+		for _, k := range append(b.MapKeys(), a.MapKeys()...) {
+			if !found[k.Interface()] {
+				found[k.Interface()] = true
+				keys = append(keys, k)
+			}
+		}
+		sortKeys(keys)
+		for _, k := range keys {
+			diffValues(fmt.Sprintf("%s[%v]", path, k), mapIndex(b, k), mapIndex(a, k), changes, seen)
+		}
+	case reflect.String:
+		if b.String() != a.String() {
+			*changes = append(*changes, FieldChange{Path: path, Before: b.String(), After: a.String()})
+		}
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// index returns the zero value past the end of a shorter slice, so added and removed strings show as changes
+func index(v reflect.Value, i int) reflect.Value {
+	if i < v.Len() {
+		return v.Index(i)
+	}
+	return reflect.Zero(v.Type().Elem())
+}
+
+func mapIndex(v reflect.Value, k reflect.Value) reflect.Value {
+	if el := v.MapIndex(k); el.IsValid() {
+		return el
+	}
+	return reflect.Zero(v.Type().Elem())
+}
+
+// zeroIfNil swaps a nil pointer for a pointer to a zero value, so it compares as empty strings
+func zeroIfNil(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return reflect.New(v.Type().Elem())
+	}
+	return v
+}
+
+// sortedKeys returns a map's keys in order, so anything reporting on maps gives the same output every run
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sortKeys(keys)
+	return keys
+}
+
+// sortKeys orders numbers numerically, strings and bools naturally, and anything else by how it prints
+func sortKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Kind() == reflect.Interface && b.Kind() == reflect.Interface {
+			a, b = a.Elem(), b.Elem()
+		}
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.String:
+				return a.String() < b.String()
+			case reflect.Bool:
+				return !a.Bool() && b.Bool()
+			}
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+}
@@ -0,0 +1,92 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestDiff() {
+	assert := assert.New(t.T())
+
+	type Address struct {
+		City string `conform:"trim,title"`
+		Zip  string `conform:"trim"`
+	}
+	type Form struct {
+		Name    string  `conform:"trim,name"`
+		Nick    *string `conform:"trim"`
+		Age     int
+		Tags    []string          `conform:"lower"`
+		Labels  map[string]string `conform:"upper"`
+		Address Address
+		Homes   []*Address
+		secret  string
+	}
+
+	nick := " al "
+	before := Form{
+		Name:    " ann ",
+		Nick:    &nick,
+		Tags:    []string{"A", "b"},
+		Labels:  map[string]string{"work": "w", "home": "h"},
+		Address: Address{City: " paris ", Zip: "75001"},
+		Homes:   []*Address{{City: "Rome"}, nil},
+		secret:  "x",
+	}
+	after, err := Copy(before)
+	assert.NoError(err)
+
+	assert.Equal([]FieldChange{
+		{Path: "Name", Before: " ann ", After: "Ann"},
+		{Path: "Nick", Before: " al ", After: "al"},
+		{Path: "Tags[0]", Before: "A", After: "a"},
+		{Path: "Labels[home]", Before: "h", After: "H"},
+		{Path: "Labels[work]", Before: "w", After: "W"},
+		{Path: "Address.City", Before: " paris ", After: "Paris"},
+	}, Diff(before, after))
+
+	assert.Equal([]FieldChange{{Path: "Name", Before: " ann ", After: "Ann"}}, Diff(&before, &Form{
+		Name: "Ann", Nick: &nick, Tags: before.Tags, Labels: before.Labels, Address: before.Address, Homes: before.Homes,
+	}), "Pointers should be compared by what they point to")
+
+	assert.Equal([]FieldChange{{Path: "[1]", Before: "", After: "b"}}, Diff([]string{"a"}, []string{"a", "b"}))
+	assert.Nil(Diff(before, &after), "Different types have nothing to compare")
+	assert.Nil(Diff(before, before))
+
+	type Node struct {
+		Name string
+		Self *Node
+	}
+	b := &Node{Name: " a "}
+	b.Self = b
+	a := &Node{Name: "a"}
+	a.Self = a
+	assert.Equal([]FieldChange{{Path: "Name", Before: " a ", After: "a"}}, Diff(b, a), "Cycles should end")
+}
+
+func (t *testSuite) TestDiffMapOrder() {
+	assert := assert.New(t.T())
+
+	before := map[int]string{10: "a", 2: "b", -1: "c"}
+	after := map[int]string{10: "A", 2: "B", -1: "C"}
+	var paths []string
+	for _, c := range Diff(before, after) {
+		paths = append(paths, c.Path)
+	}
+	assert.Equal([]string{"[-1]", "[2]", "[10]"}, paths, "Numeric keys should be in numeric order")
+}
+
+func (t *testSuite) TestMapErrorOrder() {
+	assert := assert.New(t.T())
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	for i := 0; i < 20; i++ {
+		s := struct {
+			Prices map[string]string `conform:"decimal=2"`
+		}{Prices: map[string]string{"z": "bad", "a": "worse", "m": "1"}}
+		assert.EqualError(Strings(&s), `Prices: decimal=2: "worse" is not a decimal number`, "The first key in order should be reported")
+	}
+}
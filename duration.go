@@ -0,0 +1,64 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"us": time.Microsecond, "µs": time.Microsecond, "microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "msec": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "wks": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+var durationPart = regexp.MustCompile(`([0-9]*\.?[0-9]+)\s*([^0-9\s.,]+)[\s,]*`)
+
+// duration parses human input like "1h30m", "90 minutes" or "1.5 hours" and rewrites it in time.Duration's
+// string form. Values that don't parse are returned as they are, with an error.
+func duration(s string) (string, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(s))
+	if d, err := time.ParseDuration(strings.Replace(trimmed, " ", "", -1)); err == nil {
+		return d.String(), nil
+	}
+
+	trimmed = strings.TrimSuffix(strings.Replace(trimmed, " and ", " ", -1), ".")
+	matches := durationPart.FindAllStringSubmatchIndex(trimmed, -1)
+	if len(matches) == 0 {
+		return s, fmt.Errorf("%q is not a duration", s)
+	}
+
+	var total time.Duration
+	end := 0
+	for _, m := range matches {
+		unit, ok := durationUnits[trimmed[m[4]:m[5]]]
+		if m[0] != end || !ok {
+			return s, fmt.Errorf("%q is not a duration", s)
+		}
+		n, err := strconv.ParseFloat(trimmed[m[2]:m[3]], 64)
+		if err != nil {
+			return s, fmt.Errorf("%q is not a duration", s)
+		}
+		// time.Duration holds about 292 years
+		term := n * float64(unit)
+		if term >= math.MaxInt64 || total > math.MaxInt64-time.Duration(term) {
+			return s, fmt.Errorf("%q is too long a duration", s)
+		}
+		total += time.Duration(term)
+		end = m[1]
+	}
+	if end != len(trimmed) {
+		return s, fmt.Errorf("%q is not a duration", s)
+	}
+	return total.String(), nil
+}
@@ -0,0 +1,53 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestDuration() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		"1h30m":                   "1h30m0s",
+		" 1h 30m ":                "1h30m0s",
+		"90 minutes":              "1h30m0s",
+		"1.5h":                    "1h30m0s",
+		"1.5 Hours":               "1h30m0s",
+		"2 days, 3 hours":         "51h0m0s",
+		"1 hour and 15 mins":      "1h15m0s",
+		"250ms":                   "250ms",
+		"1 week":                  "168h0m0s",
+		"soon":                    "soon",
+		"10 parsecs":              "10 parsecs",
+		"5 minutes from now, ish": "5 minutes from now, ish",
+	} {
+		var s struct {
+			Timeout string `conform:"duration"`
+		}
+		s.Timeout = in
+		Strings(&s)
+		assert.Equal(expected, s.Timeout, "duration of %q", in)
+	}
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	var s struct {
+		Timeout string `conform:"duration"`
+	}
+	s.Timeout = "soon"
+	assert.EqualError(Strings(&s), `Timeout: duration: "soon" is not a duration`)
+
+	for _, in := range []string{"300000 days", "9999999999h", "100000 weeks, 100000 weeks"} {
+		out, err := duration(in)
+		assert.EqualError(err, fmt.Sprintf("%q is too long a duration", in))
+		assert.Equal(in, out, "Durations that overflow should be left as they are")
+	}
+	out, err := duration("15250 weeks")
+	assert.NoError(err)
+	assert.Equal("2562000h0m0s", out)
+}
@@ -0,0 +1,59 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Enum is a set of allowed values for the "enum=name" tag, such as the genders or pronouns a form accepts
+type Enum struct {
+	// Values are the values allowed, as they should be written
+	Values []string
+	// Aliases map other ways of writing values to them, e.g. {"f": "female"}
+	Aliases map[string]string
+	// Default replaces values that aren't allowed, empty if unset
+	Default string
+}
+
+// enums hold the values of each registered Enum, and those of their aliases, keyed by lookupKey
+var enums = struct {
+	sync.RWMutex
+	m map[string]registeredEnum
+}{m: map[string]registeredEnum{}}
+
+type registeredEnum struct {
+	values map[string]string
+	def    string
+}
+
+// RegisterEnum registers e for use with the "enum=name" tag. Values and aliases are matched ignoring case, dots
+// and repeated whitespace, like lookup tables.
+func RegisterEnum(name string, e Enum) {
+	values := make(map[string]string, len(e.Values)+len(e.Aliases))
+	for _, v := range e.Values {
+		values[lookupKey(v)] = v
+	}
+	for alias, v := range e.Aliases {
+		values[lookupKey(alias)] = v
+	}
+	enums.Lock()
+	enums.m[name] = registeredEnum{values: values, def: e.Default}
+	enums.Unlock()
+}
+
+// enum returns the value in the enum name that s stands for. Values that aren't allowed are replaced with the
+// default, along with an error for Strict mode.
+func enum(s, name string) (string, error) {
+	enums.RLock()
+	e, ok := enums.m[name]
+	enums.RUnlock()
+	if !ok {
+		return s, fmt.Errorf("no enum registered as %q", name)
+	}
+	if v, ok := e.values[lookupKey(s)]; ok {
+		return v, nil
+	}
+	return e.def, fmt.Errorf("%q is not in %s", s, name)
+}
@@ -0,0 +1,66 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestEnum() {
+	assert := assert.New(t.T())
+
+	RegisterEnum("pronouns", Enum{
+		Values:  []string{"she/her", "he/him", "they/them"},
+		Aliases: map[string]string{"she": "she/her", "he": "he/him", "they": "they/them"},
+	})
+	RegisterEnum("gender", Enum{
+		Values:  []string{"female", "male", "nonbinary", "unspecified"},
+		Aliases: map[string]string{"f": "female", "m": "male", "non-binary": "nonbinary", "nb": "nonbinary"},
+		Default: "unspecified",
+	})
+
+	var s struct {
+		Pronouns string `conform:"trim,enum=pronouns"`
+		Gender   string `conform:"enum=gender"`
+		Other    string `conform:"enum=gender"`
+		Empty    string `conform:"enum=pronouns"`
+	}
+	s.Pronouns = " They "
+	s.Gender = "NB"
+	s.Other = "robot"
+	s.Empty = "xyz"
+	assert.NoError(Strings(&s))
+	assert.Equal("they/them", s.Pronouns)
+	assert.Equal("nonbinary", s.Gender)
+	assert.Equal("unspecified", s.Other, "Values out of the set should be replaced by the default")
+	assert.Equal("", s.Empty, "Values out of a set without a default should be emptied")
+
+	p, err := Compile("enum=gender")
+	assert.NoError(err)
+	Strict = true
+	defer func() { Strict = false }()
+	_, err = p.Apply("robot")
+	assert.EqualError(err, `enum=gender: "robot" is not in gender`)
+	_, err = p.Apply(" Female ")
+	assert.NoError(err)
+
+	// registering while fields are being conformed is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterEnum("size", Enum{Values: []string{"S", "M", "L"}})
+	}()
+	enum("m", "size")
+	wg.Wait()
+	size, err := enum("m", "size")
+	assert.NoError(err)
+	assert.Equal("M", size)
+
+	_, err = enum("x", "missing")
+	assert.EqualError(err, `no enum registered as "missing"`)
+	_, err = Compile("enum")
+	assert.EqualError(err, "enum: missing enum name")
+}
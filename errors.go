@@ -0,0 +1,23 @@
+package conform
+
+import "errors"
+
+// Errors returned by conform wrap these, along with context such as the type or tag involved, so callers can tell
+// them apart with errors.Is rather than by their messages
+var (
+	// ErrNotPointer is returned for values passed rather than pointers to them, which can't be conformed in place
+	ErrNotPointer = errors.New("Not a pointer")
+	// ErrUnsupportedKind is returned for pointers to kinds of value a function can't take, such as BindValues
+	// given a pointer to a map
+	ErrUnsupportedKind = errors.New("unsupported kind")
+	// ErrUnknownDirective is wrapped by the *StepError for a tag that's neither built in nor added with
+	// AddSanitizer
+	ErrUnknownDirective = errors.New("unknown tag")
+	// ErrUnsupportedRoot is returned, with StrictRoot set, for values other than structs, or slices or maps of
+	// them
+	ErrUnsupportedRoot = errors.New("unsupported root")
+	// ErrNotInMinimal is wrapped by the *StepError for a built in tag left out of conform_minimal builds. Unlike
+	// unknown tags, which struct tags skip, fields using them return it, so tags such as !html aren't quietly
+	// skipped.
+	ErrNotInMinimal = errors.New("not in conform_minimal builds")
+)
@@ -0,0 +1,33 @@
+package conform
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestSentinelErrors() {
+	assert := assert.New(t.T())
+
+	type user struct {
+		Name string `conform:"trim"`
+	}
+	var u user
+
+	err := Strings(u)
+	assert.ErrorIs(err, ErrNotPointer)
+	assert.EqualError(err, "Not a pointer: conform.user")
+	assert.ErrorIs(Revert(u, Undo{}), ErrNotPointer)
+	assert.ErrorIs(BindValues(url.Values{}, u), ErrNotPointer)
+
+	err = BindValues(url.Values{}, &map[string]string{})
+	assert.ErrorIs(err, ErrUnsupportedKind)
+	assert.EqualError(err, "unsupported kind: *map[string]string is not a pointer to a struct")
+
+	_, err = Compile("trim,lowr")
+	assert.ErrorIs(err, ErrUnknownDirective)
+	var step *StepError
+	assert.True(errors.As(err, &step))
+	assert.Equal("lowr", step.Tag)
+}
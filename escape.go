@@ -0,0 +1,135 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+func isASCIIAlphaNumeric(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+// cssEscape escapes s for a CSS string or identifier. Other than letters and digits, ASCII characters are
+// written as a hex escape followed by a space, which ends the escape.
+func cssEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x80 && !isASCIIAlphaNumeric(r) {
+			fmt.Fprintf(&b, "\\%x ", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// attrEscape escapes s for an HTML attribute value, quoted or not. Other than letters, digits, ",", ".", "-"
+// and "_", ASCII characters are written as hex character references.
+func attrEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x80 && !isASCIIAlphaNumeric(r) && !strings.ContainsRune(",.-_", r) {
+			fmt.Fprintf(&b, "&#x%02X;", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// shellSafe are strings a POSIX shell reads as a single word without quotes
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes s as a single word for a POSIX shell. Unless it's safe as it is, s is wrapped in single
+// quotes, which can't be escaped inside them, so each single quote ends the quoting, is escaped, and starts it
+// again.
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// likeEscape escapes the wildcards "%" and "_", and the escape character itself, so s matches literally in a
+// SQL LIKE pattern using that escape character
+func likeEscape(s string, escape rune) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '%' || r == '_' || r == escape {
+			b.WriteRune(escape)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func likeDirective(param string) (transform, error) {
+	escape := '\\'
+	if param != "" {
+		if utf8.RuneCountInString(param) != 1 || param == "%" || param == "_" {
+			return nil, fmt.Errorf("%q is not a single escape character other than %% and _", param)
+		}
+		escape, _ = utf8.DecodeRuneInString(param)
+	}
+	return func(s string) (string, error) { return likeEscape(s, escape), nil }, nil
+}
+
+// ldapEscape escapes s for a value in an LDAP search filter, as RFC 4515 requires for "*", "(", ")", "\" and
+// NUL
+var ldapEscape = strings.NewReplacer(`\`, `\5c`, "*", `\2a`, "(", `\28`, ")", `\29`, "\x00", `\00`).Replace
+
+// xpathLiteral quotes s as an XPath string literal. XPath 1.0 has no escapes, so a string with both kinds of
+// quote is built with concat().
+func xpathLiteral(s string) string {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+	parts := strings.Split(s, "'")
+	for i, p := range parts {
+		parts[i] = "'" + p + "'"
+	}
+	return "concat(" + strings.Join(parts, `, "'", `) + ")"
+}
+
+func csvDirective(param string) (transform, error) {
+	if param != "" && param != "strip" {
+		return nil, fmt.Errorf("%q is not \"strip\"", param)
+	}
+	return func(s string) (string, error) { return csvFormula(s, param == "strip"), nil }, nil
+}
+
+// csvFormula guards against formula injection in spreadsheets opened from CSV exports, where cells starting
+// with "=", "+", "-", "@", tab or carriage return are read as formulas. Such values are prefixed with "'", or
+// with strip, have those characters removed from the start. Numbers, such as "-5", are left alone.
+func csvFormula(s string, strip bool) string {
+	if s == "" || !strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return s
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s
+	}
+	if strip {
+		return strings.TrimLeft(s, "=+-@\t\r")
+	}
+	return "'" + s
+}
+
+// headerValue makes s safe to send as an HTTP header value, removing CR, LF and the other control characters
+// header values can't contain, then the spaces and tabs they can't start or end with
+func headerValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Trim(s, " \t")
+}
@@ -0,0 +1,124 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"os/exec"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http/httpguts"
+)
+
+func (t *testSuite) TestEscapes() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		CSS   string `conform:"!css"`
+		URL   string `conform:"!url"`
+		Attr  string `conform:"!attr"`
+		Shell string `conform:"!shell"`
+	}
+	s.CSS = `red;}</style><script>`
+	s.URL = "tom & jerry/é?"
+	s.Attr = `x" onmouseover="alert(1)`
+	s.Shell = "it's $(rm -rf ~)"
+	Strings(&s)
+
+	assert.Equal(`red\3b \7d \3c \2f style\3e \3c script\3e `, s.CSS)
+	assert.Equal("tom+%26+jerry%2F%C3%A9%3F", s.URL)
+	assert.Equal("x&#x22;&#x20;onmouseover&#x3D;&#x22;alert&#x28;1&#x29;", s.Attr)
+	assert.Equal(`'it'\''s $(rm -rf ~)'`, s.Shell)
+
+	assert.Equal("café", cssEscape("café"), "Non-ASCII characters should be left alone")
+	assert.Equal("a-b_c.d,e", attrEscape("a-b_c.d,e"))
+	assert.Equal("./file-1.txt", shellQuote("./file-1.txt"), "Safe words should be left unquoted")
+	assert.Equal("''", shellQuote(""))
+}
+
+func (t *testSuite) TestShellQuoteRoundTrip() {
+	assert := assert.New(t.T())
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.T().Skip("no shell")
+	}
+	for _, in := range []string{"it's", `"; echo pwned`, "$(id)", "a\nb", "''", `\`, ""} {
+		out, err := exec.Command("sh", "-c", "printf %s "+shellQuote(in)).Output()
+		assert.NoError(err, in)
+		assert.Equal(in, string(out), "The shell should read a quoted string as it was")
+	}
+}
+
+func (t *testSuite) TestLikeEscape() {
+	assert := assert.New(t.T())
+
+	assert.Equal(`100\% off\_now \\o/`, likeEscape(`100% off_now \o/`, '\\'))
+	assert.Equal(`100!% off!_now !!`, likeEscape(`100% off_now !`, '!'))
+
+	var s struct {
+		Search string `conform:"trim,!like"`
+		Bang   string `conform:"!like=!"`
+	}
+	s.Search = " 50%_ "
+	s.Bang = "50%!"
+	Strings(&s)
+	assert.Equal(`50\%\_`, s.Search)
+	assert.Equal(`50!%!!`, s.Bang)
+
+	_, err := Compile("!like=ab")
+	assert.EqualError(err, `!like=ab: "ab" is not a single escape character other than % and _`)
+	_, err = Compile("!like=%")
+	assert.Error(err)
+}
+
+func (t *testSuite) TestLDAPAndXPath() {
+	assert := assert.New(t.T())
+
+	assert.Equal(`\2a\29\28uid=\2a\29\28|\28uid=\2a`, ldapEscape(`*)(uid=*)(|(uid=*`))
+	assert.Equal(`Lucas \5c Co\00`, ldapEscape("Lucas \\ Co\x00"))
+	assert.Equal("Müller", ldapEscape("Müller"))
+
+	assert.Equal(`'bob'`, xpathLiteral("bob"))
+	assert.Equal(`"o'neill"`, xpathLiteral("o'neill"))
+	assert.Equal(`'say "hi"'`, xpathLiteral(`say "hi"`))
+	assert.Equal(`concat('it', "'", 's "x"')`, xpathLiteral(`it's "x"`))
+	assert.Equal(`''`, xpathLiteral(""))
+}
+
+func (t *testSuite) TestCSVFormula() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		`=HYPERLINK("http://evil")`: `'=HYPERLINK("http://evil")`,
+		"+1+cmd|' /C calc'!A0":      "'+1+cmd|' /C calc'!A0",
+		"-2+3":                      "'-2+3",
+		"@SUM(A1)":                  "'@SUM(A1)",
+		"\t=1":                      "'\t=1",
+		"-5":                        "-5",
+		"+1.5":                      "+1.5",
+		"hello = world":             "hello = world",
+		"":                          "",
+	} {
+		assert.Equal(want, csvFormula(in, false), in)
+	}
+
+	assert.Equal("SUM(A1)", csvFormula("=+@SUM(A1)", true))
+	assert.Equal("-5", csvFormula("-5", true))
+
+	_, err := Compile("!csv=drop")
+	assert.EqualError(err, `!csv=drop: "drop" is not "strip"`)
+}
+
+func (t *testSuite) TestHeaderValue() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"report.pdf\r\nSet-Cookie: admin=1": "report.pdfSet-Cookie: admin=1",
+		" résumé\x00.pdf\t":                 "résumé.pdf",
+		"a\tb\x7f":                          "a\tb",
+		"plain":                             "plain",
+	} {
+		out := headerValue(in)
+		assert.Equal(want, out, in)
+		assert.True(httpguts.ValidHeaderFieldValue(out), "The result should be a valid header value")
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var ethAddress = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+
+// ethAddr writes an Ethereum address with the mixed case checksum of EIP-55, where each letter is uppercased
+// when the matching nibble of the Keccak-256 hash of the lowercase address is 8 or more
+func ethAddr(s string) (string, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	if !strings.HasPrefix(lower, "0x") {
+		lower = "0x" + lower
+	}
+	if !ethAddress.MatchString(lower) {
+		return s, fmt.Errorf("%q is not an Ethereum address", s)
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower[2:]))
+	hash := hex.EncodeToString(h.Sum(nil))
+
+	out := []byte(lower)
+	for i := 2; i < len(out); i++ {
+		if out[i] >= 'a' && hash[i-2] >= '8' {
+			out[i] -= 'a' - 'A'
+		}
+	}
+	return string(out), nil
+}
@@ -0,0 +1,35 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestEthAddr() {
+	assert := assert.New(t.T())
+
+	// test vectors from EIP-55
+	for _, want := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0xde709f2102306220921060314715629080e2fb77",
+	} {
+		for _, in := range []string{want, strings.ToLower(want), strings.ToUpper(want[2:]), " " + want + " "} {
+			out, err := ethAddr(in)
+			assert.NoError(err, in)
+			assert.Equal(want, out, in)
+		}
+	}
+
+	for _, in := range []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", "0xZaAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ""} {
+		out, err := ethAddr(in)
+		assert.Error(err, in)
+		assert.Equal(in, out)
+	}
+}
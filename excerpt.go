@@ -0,0 +1,59 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	htmlBlocks = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)\s*>`)
+	htmlTags   = regexp.MustCompile(`(?s)<\s*/?\s*([a-zA-Z][a-zA-Z0-9]*)?[^>]*>`)
+)
+
+// blockElements are the elements that start a new line, so their tags are replaced with a space rather than
+// joining the words either side of them
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true, "main": true, "nav": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+// stripHTML removes tags, scripts and styles, and unescapes entities. Inline tags, such as <b>, are removed
+// without a trace, so "<b>world</b>," stays "world,", while block tags, such as <p> and <br>, leave a space.
+func stripHTML(s string) string {
+	s = htmlBlocks.ReplaceAllLiteralString(s, " ")
+	s = htmlTags.ReplaceAllStringFunc(s, func(tag string) string {
+		if blockElements[strings.ToLower(htmlTags.FindStringSubmatch(tag)[1])] {
+			return " "
+		}
+		return ""
+	})
+	return html.UnescapeString(s)
+}
+
+// excerpt produces plain text of at most max characters, including a trailing ellipsis when it's truncated
+func excerpt(s string, max int) string {
+	text := strings.Join(strings.Fields(noMarkdown(stripHTML(s))), " ")
+	buf := getRunes(text)
+	defer putRunes(buf)
+	rs := *buf
+	if max <= 0 || len(rs) <= max {
+		return text
+	}
+	cut := rs[:max-1]
+	// back up to a word boundary, unless the first word alone is too long
+	for i := len(cut); i > 0; i-- {
+		if unicode.IsSpace(rs[i]) {
+			cut = rs[:i]
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
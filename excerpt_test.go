@@ -0,0 +1,59 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestExcerpt() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		HTML     string `conform:"excerpt=20"`
+		Markdown string `conform:"excerpt=30"`
+		Short    string `conform:"excerpt=160"`
+		LongWord string `conform:"excerpt=10"`
+	}
+
+	s.HTML = "<p>The <b>quick</b> brown fox jumps over the lazy dog</p><script>alert(1)</script>"
+	s.Markdown = "# Release notes\n\nWe **shipped** the [new API](https://example.com), finally."
+	s.Short = "  Just &amp; a   short\n\nline "
+	s.LongWord = "Supercalifragilistic"
+	Strings(&s)
+
+	assert.Equal("The quick brown fox…", s.HTML)
+	assert.Equal("Release notes We shipped the…", s.Markdown)
+	assert.Equal("Just & a short line", s.Short)
+	assert.Equal("Supercali…", s.LongWord)
+
+	for in, want := range map[string]string{
+		"Hello <b>world</b>, this is <em>it</em>.": "Hello world, this is it.",
+		"<p>One</p><p>Two</p>Three<br/>Four":       " One  Two Three Four",
+		"<LI>item</LI><li>next":                    " item  next",
+		"a<!-- note -->b":                          "ab",
+	} {
+		assert.Equal(want, stripHTML(in), in)
+	}
+}
+
+func (t *testSuite) TestMaxWords() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"one two three four":        "one two three",
+		"  one,  two\nthree. four ": "  one,  two\nthree.",
+		"one two":                   "one two",
+		"one two three   ":          "one two three",
+		"":                          "",
+	} {
+		assert.Equal(want, maxWords(in, 3), "%q", in)
+	}
+
+	var s struct {
+		Bio string `conform:"trim,maxwords=2"`
+	}
+	s.Bio = " Gopher, climber and cook "
+	Strings(&s)
+	assert.Equal("Gopher, climber", s.Bio)
+}
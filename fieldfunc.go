@@ -0,0 +1,80 @@
+package conform
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FieldContext is what a func registered with RegisterFieldFunc is given
+type FieldContext struct {
+	// Parent is a pointer to the struct holding the field, for logic that depends on other fields
+	Parent interface{}
+	// Path locates the field from the value being conformed, e.g. "SKU", "Items[0].SKU" or "Labels[home]"
+	Path string
+	// Value is the field's value after the tags before "func=" in its chain
+	Value string
+
+	out *string
+	// fired, when set, collects the tags that change the field, for logging
+	fired *[]string
+	// metrics, when set, counts the tags applied to the field
+	metrics *Metrics
+}
+
+// Set replaces the field's value. Tags after "func=" carry on from it.
+func (c FieldContext) Set(s string) {
+	*c.out = s
+}
+
+// FieldFunc conforms a field with access to the rest of its struct
+type FieldFunc func(ctx FieldContext) error
+
+var fieldFuncs = struct {
+	sync.RWMutex
+	m map[string]FieldFunc
+}{m: map[string]FieldFunc{}}
+
+// RegisterFieldFunc associates fn with a name, so that the tag "func=name" runs it. Unlike sanitizers, which
+// only see a string, fn is given the struct holding the field, so can implement logic across fields.
+func RegisterFieldFunc(name string, fn FieldFunc) {
+	fieldFuncs.Lock()
+	fieldFuncs.m[name] = fn
+	fieldFuncs.Unlock()
+}
+
+// hasFieldFuncs reports whether any funcs have been registered
+func hasFieldFuncs() bool {
+	fieldFuncs.RLock()
+	defer fieldFuncs.RUnlock()
+	return len(fieldFuncs.m) > 0
+}
+
+func fieldFunc(name string) (FieldFunc, bool) {
+	fieldFuncs.RLock()
+	defer fieldFuncs.RUnlock()
+	fn, ok := fieldFuncs.m[name]
+	return fn, ok
+}
+
+// funcDirective checks the tag names a func. Funcs are looked up when applied, so they can be registered after
+// the tag is first used, and only run on struct fields, as there's no struct to give them otherwise.
+func funcDirective(param string) (transform, error) {
+	if param == "" {
+		return nil, errors.New("missing func name")
+	}
+	return func(s string) (string, error) {
+		return s, errors.New("can only be applied to struct fields")
+	}, nil
+}
+
+// applyFunc runs the func named name on a field whose value is s
+func applyFunc(name, s string, ctx FieldContext) (string, error) {
+	fn, ok := fieldFunc(name)
+	if !ok {
+		return s, fmt.Errorf("unknown func %q", name)
+	}
+	ctx.Value, ctx.out = s, &s
+	err := fn(ctx)
+	return s, err
+}
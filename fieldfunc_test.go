@@ -0,0 +1,76 @@
+package conform
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type skuItem struct {
+	Brand string `conform:"upper"`
+	SKU   string `conform:"trim,func=test_sku,lower"`
+}
+
+func (t *testSuite) TestFieldFunc() {
+	assert := assert.New(t.T())
+
+	var paths []string
+	RegisterFieldFunc("test_sku", func(ctx FieldContext) error {
+		paths = append(paths, ctx.Path)
+		item := ctx.Parent.(*skuItem)
+		if !strings.HasPrefix(ctx.Value, item.Brand+"-") {
+			ctx.Set(item.Brand + "-" + ctx.Value)
+		}
+		return nil
+	})
+
+	var order struct {
+		Main  skuItem
+		Items []skuItem
+	}
+	order.Main = skuItem{Brand: "acme", SKU: " X1 "}
+	order.Items = []skuItem{{Brand: "ACME", SKU: "ACME-Y2"}}
+
+	assert.NoError(Strings(&order))
+	assert.Equal("acme-x1", order.Main.SKU, "The func should see the conformed Brand and its output carry on down the chain")
+	assert.Equal("acme-y2", order.Items[0].SKU)
+	assert.Equal([]string{"Main.SKU", "Items[0].SKU"}, paths)
+
+	paths = nil
+	order.Main.SKU = "z3"
+	assert.NoError(Fields(&order, "Main.SKU"))
+	assert.Equal("acme-z3", order.Main.SKU)
+	assert.Equal([]string{"Main.SKU"}, paths)
+}
+
+func (t *testSuite) TestFieldFuncErrors() {
+	assert := assert.New(t.T())
+
+	RegisterFieldFunc("test_fail", func(ctx FieldContext) error {
+		return errors.New("no")
+	})
+
+	var s struct {
+		A string `conform:"func=test_fail"`
+	}
+	s.A = "a"
+	assert.NoError(Strings(&s))
+	assert.EqualError(StringsWithOptions(&s, Options{Strict: true}), "A: func=test_fail: no")
+
+	var u struct {
+		A string `conform:"func=test_missing"`
+	}
+	assert.EqualError(StringsWithOptions(&u, Options{Strict: true}), `A: func=test_missing: unknown func "test_missing"`)
+
+	_, err := Compile("func=")
+	assert.EqualError(err, "func=: missing func name")
+
+	_, err = Compile("func=test_fail|trim")
+	assert.EqualError(err, "func=test_fail|trim: func can't be used with |")
+
+	p, err := Compile("func=test_fail")
+	assert.NoError(err)
+	_, err = p.apply("a", true)
+	assert.EqualError(err, "func=test_fail: can only be applied to struct fields")
+}
@@ -0,0 +1,113 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Fields conforms only the fields at paths, such as "Email" or "Profile.Bio", leaving the rest of the struct
+// alone. A path naming a struct conforms everything in it. Each name in a path is a field's Go name or, as in
+// field masks, the name in its `protobuf` or `json` tag. Paths through nil pointers are skipped, and paths
+// that don't name a field return an error.
+func Fields(iface interface{}, paths ...string) error {
+	return FieldsWithOptions(iface, Options{Strict: Strict}, paths...)
+}
+
+// PathLister holds the paths of the fields a request sets, such as a *fieldmaskpb.FieldMask
+type PathLister interface {
+	GetPaths() []string
+}
+
+// FieldMask conforms only the fields in mask, as after a partial update. Following field mask conventions, an
+// empty mask or the path "*" conforms the whole struct.
+func FieldMask(iface interface{}, mask PathLister) error {
+	var paths []string
+	if mask != nil {
+		paths = mask.GetPaths()
+	}
+	if len(paths) == 0 {
+		return Strings(iface)
+	}
+	return Fields(iface, paths...)
+}
+
+// FieldsWithOptions conforms only the fields at paths, like Fields, configured by opts
+func FieldsWithOptions(iface interface{}, opts Options, paths ...string) error {
+	ifv := reflect.ValueOf(iface)
+	if ifv.Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: %T", ErrNotPointer, iface)
+	}
+	w := newWalker(opts)
+	for _, path := range paths {
+		if path == "*" {
+			if err := w.conformStruct(iface, 0); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := w.conformPath(ifv.Elem(), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conformPath follows path down from v, one field name at a time, and conforms the field at the end of it. The
+// whole path is checked against v's type, even when a nil pointer means there's nothing to conform.
+func (w walker) conformPath(v reflect.Value, path string) error {
+	names := strings.Split(path, ".")
+	t := v.Type()
+	for depth, name := range names {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("unknown field %q", path)
+		}
+		var err error
+		if w, err = w.versioned(t); err != nil {
+			return err
+		}
+		f, ok := fieldByName(t, name)
+		if !ok {
+			return fmt.Errorf("unknown field %q", path)
+		}
+		t = f.Type
+
+		if v.IsValid() {
+			parent := v.Addr().Interface()
+			w = w.at(parent, w.fieldPath(f.Name))
+			// nil pointers, embedded or not, hold nothing to conform
+			v, _ = v.FieldByIndexErr(f.Index)
+			if v.Kind() == reflect.Ptr {
+				v = v.Elem()
+			}
+		}
+		if depth == len(names)-1 && v.IsValid() {
+			if err := w.conformField(f, v, depth); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldByName finds a field by its Go name, or the name in its `protobuf` or `json` tag
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool) {
+	if f, ok := t.FieldByName(name); ok {
+		return f, true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if tag := f.Tag.Get("json"); tag != "" && strings.Split(tag, ",")[0] == name {
+			return f, true
+		}
+		for _, opt := range strings.Split(f.Tag.Get("protobuf"), ",") {
+			if opt == "name="+name {
+				return f, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
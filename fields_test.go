@@ -0,0 +1,102 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestFields() {
+	assert := assert.New(t.T())
+
+	type profile struct {
+		Bio     string `conform:"trim"`
+		Website string `conform:"trim"`
+	}
+	type user struct {
+		Email   string `conform:"email"`
+		Name    string `conform:"name"`
+		Profile *profile
+		Prefs   profile
+	}
+
+	u := user{
+		Email:   " Bob@EXAMPLE.com ",
+		Name:    " bob ",
+		Profile: &profile{Bio: " hi ", Website: " example.com "},
+		Prefs:   profile{Bio: " a ", Website: " b "},
+	}
+	assert.NoError(Fields(&u, "Email", "Profile.Bio", "Prefs"))
+	assert.Equal("Bob@example.com", u.Email)
+	assert.Equal(" bob ", u.Name, "Unlisted fields should be left alone")
+	assert.Equal("hi", u.Profile.Bio)
+	assert.Equal(" example.com ", u.Profile.Website, "Unlisted nested fields should be left alone")
+	assert.Equal(profile{Bio: "a", Website: "b"}, u.Prefs, "A path naming a struct should conform all of it")
+
+	u.Profile = nil
+	assert.NoError(Fields(&u, "Profile.Bio"), "Paths through nil pointers should be skipped")
+
+	assert.EqualError(Fields(&u, "Profile.Bioo"), `unknown field "Profile.Bioo"`)
+	assert.EqualError(Fields(&u, "Email.Domain"), `unknown field "Email.Domain"`)
+	assert.ErrorIs(Fields(u, "Email"), ErrNotPointer)
+}
+
+func (t *testSuite) TestFieldsStrict() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Order struct {
+			Price string `conform:"decimal=2"`
+			Notes string `conform:"decimal=2"`
+		}
+	}
+	s.Order.Price = "abc"
+	s.Order.Notes = "1"
+
+	err := FieldsWithOptions(&s, Options{Strict: true}, "Order.Notes", "Order.Price")
+	assert.EqualError(err, `Order.Price: decimal=2: "abc" is not a decimal number`)
+	assert.Equal("1.00", s.Order.Notes)
+}
+
+type paths []string
+
+func (p paths) GetPaths() []string {
+	return p
+}
+
+func (t *testSuite) TestFieldMask() {
+	assert := assert.New(t.T())
+
+	// shaped like generated protobuf code
+	type profile struct {
+		Bio string `protobuf:"bytes,1,opt,name=bio,proto3" json:"bio,omitempty" conform:"trim"`
+	}
+	type user struct {
+		DisplayName string   `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty" conform:"trim"`
+		Email       string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty" conform:"email"`
+		Profile     *profile `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	}
+	fresh := func() user {
+		return user{DisplayName: " Bob ", Email: " Bob@EXAMPLE.com ", Profile: &profile{Bio: " hi "}}
+	}
+
+	u := fresh()
+	assert.NoError(FieldMask(&u, paths{"display_name", "profile.bio"}))
+	assert.Equal("Bob", u.DisplayName)
+	assert.Equal(" Bob@EXAMPLE.com ", u.Email, "Fields outside the mask should be left alone")
+	assert.Equal("hi", u.Profile.Bio)
+
+	u = fresh()
+	assert.NoError(FieldMask(&u, paths{"*"}))
+	assert.Equal("Bob@example.com", u.Email, "The path * should conform everything")
+
+	u = fresh()
+	assert.NoError(FieldMask(&u, paths{}))
+	assert.Equal("Bob@example.com", u.Email, "An empty mask should conform everything")
+
+	u = fresh()
+	assert.NoError(FieldMask(&u, nil))
+	assert.Equal("Bob", u.DisplayName, "A nil mask should conform everything")
+
+	assert.EqualError(FieldMask(&u, paths{"nickname"}), `unknown field "nickname"`)
+}
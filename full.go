@@ -0,0 +1,181 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/etgryphon/stringUp"
+)
+
+// fullDirectives are the built in tags left out of minimal builds, those made with -tags conform_minimal, as
+// they need regular expressions, large tables or golang.org/x/text
+var fullDirectives = map[string]directive{
+	"title":    titleDirective,
+	"camel":    plain(stringUp.CamelCase),
+	"snake":    plain(func(s string) string { return camelTo(stringUp.CamelCase(s), "_") }),
+	"slug":     plain(func(s string) string { return camelTo(stringUp.CamelCase(s), "-") }),
+	"name":     plain(formatName),
+	"num":      plain(onlyNumbers),
+	"!num":     plain(stripNumbers),
+	"alpha":    plain(onlyAlpha),
+	"!alpha":   plain(stripAlpha),
+	"!html":    plain(template.HTMLEscapeString),
+	"!js":      plain(template.JSEscapeString),
+	"!css":     plain(cssEscape),
+	"!url":     plain(url.QueryEscape),
+	"!attr":    plain(attrEscape),
+	"!shell":   plain(shellQuote),
+	"!like":    likeDirective,
+	"!ldap":    plain(ldapEscape),
+	"!xpath":   plain(xpathLiteral),
+	"!header":  plain(headerValue),
+	"!csv":     csvDirective,
+	"country":  plain(country),
+	"currency": plain(currency),
+	"tzname":   plain(tzName),
+	"langtag":  plain(langTag),
+	"usstate":  plain(func(s string) string { return lookup(s, "usstate") }),
+	"postal": func(param string) (transform, error) {
+		if _, ok := postalFormats[strings.ToUpper(param)]; !ok {
+			return nil, fmt.Errorf("unknown country %q", param)
+		}
+		return func(s string) (string, error) { return postal(s, param), nil }, nil
+	},
+	"lookup": func(param string) (transform, error) {
+		if param == "" {
+			return nil, errors.New("missing table name")
+		}
+		// tables are looked up when applied, so they can be registered after the tag is first used
+		return func(s string) (string, error) { return lookup(s, param), nil }, nil
+	},
+	"decimal": func(param string) (transform, error) {
+		places, err := strconv.Atoi(param)
+		if err != nil || places < 0 {
+			return nil, fmt.Errorf("%q is not a whole number of at least 0", param)
+		}
+		return func(s string) (string, error) { return decimal(s, places) }, nil
+	},
+	"decimal_sep": func(param string) (transform, error) {
+		// "comma" is kept for chains written before a comma could be given, as with trimchars
+		sep, ok := map[string]string{".": ".", "dot": ".", ",": ",", "comma": ","}[param]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a decimal separator, use \".\" or \",\"", param)
+		}
+		return func(s string) (string, error) { return decimalSep(s, sep) }, nil
+	},
+	"duration":      fallible(duration),
+	"hexcolor":      fallible(hexcolor),
+	"mac":           fallible(mac),
+	"ip":            fallible(ip),
+	"mention_strip": plain(mentionStrip),
+	"handle": func(param string) (transform, error) {
+		if param == "" {
+			return func(s string) (string, error) { return handle(s, 0), nil }, nil
+		}
+		return withInt(1, handle)(param)
+	},
+	"hashtags":       plain(hashtags),
+	"url_notracking": plain(urlNoTracking),
+	"url_samehost": func(param string) (transform, error) {
+		if param == "" {
+			return nil, errors.New("missing allowed hosts")
+		}
+		return func(s string) (string, error) { return urlSameHost(s, param), nil }, nil
+	},
+	"url_public":      fallible(urlPublic),
+	"nomarkdown":      plain(noMarkdown),
+	"md_normalize":    plain(mdNormalize),
+	"excerpt":         withInt(2, excerpt),
+	"thousands_strip": plain(thousandsStrip),
+	"decode":          charsetDirective,
+	"confusables":     plain(confusables),
+	"company":         plain(company),
+	"latlon":          latLonDirective,
+	"isbn":            isbnDirective,
+	"issn":            fallible(issn),
+	"ean":             fallible(ean),
+	"vin":             fallible(vin),
+	"ein":             fallible(ein),
+	"aba":             fallible(aba),
+	"sortcode":        fallible(sortCode),
+	"swift":           fallible(swift),
+	"ethaddr":         fallible(ethAddr),
+	"token":           fallible(token),
+	"key_flatten":     plain(keyFlatten),
+	"key_brackets":    plain(keyBrackets),
+	"singular":        plain(func(s string) string { return inflect(s, singular) }),
+	"plural":          plain(func(s string) string { return inflect(s, plural) }),
+	"translit": func(param string) (transform, error) {
+		if param == "" {
+			return nil, errors.New("missing transliterator name")
+		}
+		return func(s string) (string, error) { return transliterate(s, param) }, nil
+	},
+	"enum": func(param string) (transform, error) {
+		if param == "" {
+			return nil, errors.New("missing enum name")
+		}
+		// enums are looked up when applied, so they can be registered after the tag is first used
+		return func(s string) (string, error) { return enum(s, param) }, nil
+	},
+	"digits_to_words": func(param string) (transform, error) {
+		return func(s string) (string, error) { return digitsToWords(s, param) }, nil
+	},
+	"words_to_digits": func(param string) (transform, error) {
+		return func(s string) (string, error) { return wordsToDigits(s, param) }, nil
+	},
+	"soundex":   plain(func(s string) string { return phonetic(s, soundex) }),
+	"metaphone": plain(func(s string) string { return phonetic(s, metaphone) }),
+	"mrz":       mrzDirective,
+	"ssn":       ssnDirective,
+	"vatid":     vatIDDirective,
+	"plate":     plateDirective,
+	"searchkey": func(param string) (transform, error) {
+		if param != "" && param != "stem" {
+			return nil, fmt.Errorf("%q is not \"stem\"", param)
+		}
+		return func(s string) (string, error) { return searchKey(s, param == "stem"), nil }, nil
+	},
+}
+
+func init() {
+	for name, d := range fullDirectives {
+		directives[name] = d
+	}
+}
+
+// elementDirectives replace directives of the same name after "dive"
+var elementDirectives = map[string]directive{
+	"hashtags": plain(normaliseHashtag),
+}
+
+var patterns = map[string]*regexp.Regexp{
+	"numbers":    regexp.MustCompile("[0-9]"),
+	"nonNumbers": regexp.MustCompile("[^0-9]"),
+	"alpha":      regexp.MustCompile("[\\pL]"),
+	"nonAlpha":   regexp.MustCompile("[^\\pL]"),
+	"name":       regexp.MustCompile("[\\p{L}]([\\p{L}|[:space:]|\\-|\\']*[\\p{L}])*"),
+}
+
+func onlyNumbers(s string) string {
+	return patterns["nonNumbers"].ReplaceAllLiteralString(s, "")
+}
+
+func stripNumbers(s string) string {
+	return patterns["numbers"].ReplaceAllLiteralString(s, "")
+}
+
+func onlyAlpha(s string) string {
+	return patterns["nonAlpha"].ReplaceAllLiteralString(s, "")
+}
+
+func stripAlpha(s string) string {
+	return patterns["alpha"].ReplaceAllLiteralString(s, "")
+}
@@ -0,0 +1,287 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/icrowley/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestFullTags() {
+	assert := assert.New(t.T())
+
+	var names []string
+	for name := range fullDirectives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(names, fullTags, "fullTags should list the tags in fullDirectives, in order")
+}
+
+func (t *testSuite) TestCamel() {
+	assert := assert.New(t.T())
+
+	for i := 0; i < 10000; i++ {
+		var s struct {
+			Dashes      string `conform:"camel"`
+			Underscores string `conform:"camel"`
+			Spaces      string `conform:"camel"`
+		}
+		s.Dashes = fmt.Sprintf("%s-%s", fake.FirstName(), fake.LastName())
+		s.Underscores = fmt.Sprintf("%s_%s", fake.FirstName(), fake.LastName())
+		s.Spaces = fmt.Sprintf("%s %s", fake.FirstName(), fake.LastName())
+		Strings(&s)
+		if ok := assert.Regexp(t.RegExCamel, s.Dashes, "Dashes should be CamelCased"); !ok {
+			break
+		}
+		if ok := assert.Regexp(t.RegExCamel, s.Underscores, "Underscores should be CamelCased"); !ok {
+			break
+		}
+		if ok := assert.Regexp(t.RegExCamel, s.Spaces, "Spaces should be CamelCased"); !ok {
+			break
+		}
+	}
+
+}
+
+func (t *testSuite) TestSnake() {
+	assert := assert.New(t.T())
+
+	for i := 0; i < 10000; i++ {
+		var s struct {
+			Camel  string `conform:"snake"`
+			Spaces string `conform:"snake"`
+		}
+		s.Camel = fmt.Sprintf("%s%s", fake.FirstName(), fake.LastName())
+		s.Spaces = fmt.Sprintf("%s %s", fake.FirstName(), fake.LastName())
+		Strings(&s)
+		if ok := assert.Regexp(t.RegExSnake, s.Camel, "CamelCase should be snake_case"); !ok {
+			break
+		}
+		if ok := assert.Regexp(t.RegExSnake, s.Spaces, "Spaces should be snake_case"); !ok {
+			break
+		}
+	}
+
+}
+
+func (t *testSuite) TestSlug() {
+	assert := assert.New(t.T())
+
+	for i := 0; i < 10000; i++ {
+		var s struct {
+			Camel  string `conform:"slug"`
+			Spaces string `conform:"slug"`
+		}
+		s.Camel = fmt.Sprintf("%s%s", fake.FirstName(), fake.LastName())
+		s.Spaces = fmt.Sprintf("%s %s", fake.FirstName(), fake.LastName())
+		Strings(&s)
+		if ok := assert.Regexp(t.RegExSlug, s.Camel, "CamelCase should be slug-case"); !ok {
+			break
+		}
+		if ok := assert.Regexp(t.RegExSlug, s.Spaces, "Spaces should be slug-case"); !ok {
+			break
+		}
+	}
+
+}
+
+func (t *testSuite) TestTitle() {
+	assert := assert.New(t.T())
+
+	for i := 0; i < 10000; i++ {
+		var s struct {
+			FullName string `conform:"title"`
+		}
+		s.FullName = strings.ToLower(fake.FullName())
+		Strings(&s)
+		if ok := assert.Regexp(t.RegExTitle, s.FullName, "Full name should be Title Cased"); !ok {
+			break
+		}
+	}
+}
+
+func (t *testSuite) TestNumbersInName() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Name string `conform:"name"`
+	}
+
+	fn := fake.FirstName()
+	s.Name = "3847" + fn + "49"
+	Strings(&s)
+	assert.Equal(fn, s.Name, "Name should have numbers removed")
+}
+
+func (t *testSuite) TestOnlyNumbers() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Price string `conform:"num"`
+	}
+
+	s.Price = "the price is €30,38; pay up!"
+	expected := "3038"
+	Strings(&s)
+	assert.Equal(expected, s.Price, "Price should have non-numerical digits removed")
+}
+
+func (t *testSuite) TestStripNum() {
+	assert := assert.New(t.T())
+
+	for i := 0; i < 10000; i++ {
+		var s struct {
+			Name string `conform:"!num"`
+		}
+
+		fn := fake.FirstName()
+		s.Name = t.randomNumberString() + fn + t.randomNumberString()
+		Strings(&s)
+		if ok := assert.Equal(fn, s.Name, "Name should have numbers stripped"); !ok {
+			break
+		}
+	}
+}
+
+func (t *testSuite) TestOnlyAlpha() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Title string `conform:"alpha"`
+	}
+
+	s.Title = t.randomNumberString() + "準" + t.randomNumberString() + "'!@£$従う%^&*()" + "準"
+	expected := "準従う準"
+	Strings(&s)
+	assert.Equal(expected, s.Title, "Title should strip non-alpha characters")
+}
+
+func (t *testSuite) TestStripAlpha() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Title string `conform:"!alpha"`
+	}
+
+	s.Title = "Everything's here but the letters!"
+	expected := "'    !"
+	Strings(&s)
+	assert.Equal(expected, s.Title, "Title should strip alpha characters")
+}
+
+func (t *testSuite) TestWeirdNames() {
+	assert := assert.New(t.T())
+
+	// must contain %s x 6, with any combo before/after
+	formats := []string{
+		"%s%s-%s%s-%s%s",      // squashed together
+		"    %s%s%s-%s%s%s",   // leading spaces
+		"%s%s%s-%s%s%s     ",  // trailing spaces
+		"~%s£%s$%s-%s*%s(%s)", // single special characters
+		"%s'%s%s-%s%s''%s",    // name with apostrophes
+		"%s     %s%s-%s%s%s",  // multiple whitespaces
+		"%s%s%s  -  %s%s%s",   // name with whitespace enclosed hyphen
+	}
+
+F:
+	for _, f := range formats {
+
+		for i := 0; i < 1000; i++ {
+			var s struct {
+				Name string `conform:"name"`
+			}
+
+			fn := fake.FirstName()
+			ln := t.lastName()
+
+			s.Name = fmt.Sprintf(f,
+				t.randomNumberString(),
+				fn,
+				t.randomNumberString(),
+				t.randomNumberString(),
+				ln,
+				t.randomNumberString(),
+			)
+			orig := s.Name
+			Strings(&s)
+			if ok := assert.Equal(s.Name, fmt.Sprintf("%s-%s", fn, ln), "Name shouldn't have any weird characters"); !ok {
+				fmt.Println("Originally: " + orig)
+				break F
+			}
+		}
+
+	}
+
+}
+
+func (t *testSuite) TestEmbeddedStructfn() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		TestEmbeddedStruct
+		LastName string `conform:"name"`
+	}
+
+	fn := fake.FirstName()
+	ln := t.lastName()
+
+	s.FirstName = t.randomNumberString() + fn + t.randomNumberString()
+	s.LastName = t.randomNumberString() + ln + t.randomNumberString()
+	Strings(&s)
+
+	assert.Equal(fn, s.FirstName, "First name should be stripped of numbers")
+	assert.Equal(ln, s.LastName, "Last name should be stripped of numbers")
+}
+
+func (t *testSuite) TestTwiceEmbeddedStructFn() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		TestTwiceEmbeddedStruct
+		Country string `conform:"trim,upper"`
+	}
+
+	fn := fake.FirstName()
+	ln := t.lastName()
+	country := "United Kingdom"
+
+	s.FirstName = t.randomNumberString() + fn + t.randomNumberString()
+	s.LastName = t.randomNumberString() + ln + t.randomNumberString()
+	s.Country = country
+	Strings(&s)
+
+	assert.Equal(fn, s.FirstName, "First name should be stripped of numbers")
+	assert.Equal(ln, s.LastName, "Last name should be stripped of numbers")
+	assert.Equal(s.Country, "UNITED KINGDOM", "Last name should be stripped of numbers")
+}
+
+func (t *testSuite) TestThriceEmbeddedStructFn() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		TestThriceEmbeddedStruct
+		Country string `conform:"trim,upper"`
+	}
+
+	fn := fake.FirstName()
+	ln := t.lastName()
+	email := fake.EmailAddress()
+	country := "United Kingdom"
+
+	s.FirstName = t.randomNumberString() + fn + t.randomNumberString()
+	s.LastName = t.randomNumberString() + ln + t.randomNumberString()
+	s.Email = email
+	s.Country = country
+	Strings(&s)
+
+	assert.Equal(fn, s.FirstName, "First name should be stripped of numbers")
+	assert.Equal(ln, s.LastName, "Last name should be stripped of numbers")
+	assert.Equal(emailLocalPart(email), emailLocalPart(s.Email), "E-mail local part should not change")
+	assert.Equal(strings.ToLower(emailDomainPart(email)), emailDomainPart(s.Email), "E-mail domain part should be lowercase")
+	assert.Equal(s.Country, "UNITED KINGDOM", "Last name should be stripped of numbers")
+}
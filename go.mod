@@ -1,10 +1,20 @@
 module github.com/leebenson/conform
 
-go 1.13
+go 1.18
 
 require (
-	github.com/corpix/uarand v0.1.1 // indirect
 	github.com/etgryphon/stringUp v0.0.0-20121020160746-31534ccd8cac
 	github.com/icrowley/fake v0.0.0-20180203215853-4178557ae428
-	github.com/stretchr/testify v1.6.0
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/crypto v0.24.0
+	golang.org/x/net v0.26.0
+	golang.org/x/text v0.16.0
+)
+
+require (
+	github.com/corpix/uarand v0.1.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,109 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// smallWords stay lowercase in titles, unless first or last, keyed by language
+var smallWords = struct {
+	sync.RWMutex
+	m map[string]map[string]bool
+}{m: map[string]map[string]bool{}}
+
+func init() {
+	RegisterSmallWords("en", "a", "an", "and", "as", "at", "but", "by", "for", "from", "in", "into", "nor", "of",
+		"on", "or", "per", "the", "to", "vs", "via", "with")
+	RegisterSmallWords("fr", "à", "au", "aux", "de", "des", "du", "en", "et", "la", "le", "les", "ou", "par",
+		"pour", "sur", "un", "une")
+	RegisterSmallWords("es", "a", "con", "de", "del", "e", "el", "en", "la", "las", "los", "o", "para", "por",
+		"u", "un", "una", "y")
+	RegisterSmallWords("it", "a", "al", "con", "da", "del", "della", "di", "e", "gli", "i", "il", "in", "la", "le",
+		"lo", "o", "per", "su", "un", "una")
+}
+
+// RegisterSmallWords adds words that the "title=lang" tag leaves lowercase, unless they start or end the title,
+// for the language lang, e.g. "en" or "fr"
+func RegisterSmallWords(lang string, words ...string) {
+	base := baseLanguage(lang)
+	smallWords.Lock()
+	defer smallWords.Unlock()
+	if smallWords.m[base] == nil {
+		smallWords.m[base] = map[string]bool{}
+	}
+	for _, w := range words {
+		smallWords.m[base][strings.ToLower(w)] = true
+	}
+}
+
+// isSmallWord reports whether word is one of the small words of base, a language from baseLanguage
+func isSmallWord(base, word string) bool {
+	smallWords.RLock()
+	defer smallWords.RUnlock()
+	return smallWords.m[base][word]
+}
+
+// baseLanguage reduces a language tag to its language, so "en-GB" uses the words for "en"
+func baseLanguage(lang string) string {
+	base, _ := language.Make(lang).Base()
+	return base.String()
+}
+
+// headline title cases s in an editorial style, where the small words of lang stay lowercase unless they start
+// or end the title, or follow a colon
+func headline(s, lang string) string {
+	base := baseLanguage(lang)
+	title := cases.Title(language.Make(lang), cases.NoLower)
+
+	// find the words, so the whitespace between them can be kept as it is
+	var words [][2]int
+	start := -1
+	for i, r := range s {
+		if !unicode.IsSpace(r) && start == -1 {
+			start = i
+		} else if unicode.IsSpace(r) && start != -1 {
+			words = append(words, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start != -1 {
+		words = append(words, [2]int{start, len(s)})
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	prev := 0
+	for i, span := range words {
+		b.WriteString(s[prev:span[0]])
+		w := s[span[0]:span[1]]
+		bare := strings.ToLower(strings.TrimFunc(w, unicode.IsPunct))
+		first := i == 0 || strings.HasSuffix(s[words[i-1][0]:words[i-1][1]], ":")
+		if !first && i != len(words)-1 && isSmallWord(base, bare) {
+			b.WriteString(strings.ToLower(w))
+		} else {
+			b.WriteString(title.String(w))
+		}
+		prev = span[1]
+	}
+	b.WriteString(s[prev:])
+	return b.String()
+}
+
+// titleDirective title cases each word, like strings.Title, or with a language, such as "title=en", in an
+// editorial style that leaves that language's small words lowercase
+func titleDirective(param string) (transform, error) {
+	if param == "" {
+		return plain(strings.Title)(param)
+	}
+	if _, err := language.Parse(param); err != nil {
+		return nil, fmt.Errorf("%q is not a language", param)
+	}
+	return func(s string) (string, error) { return headline(s, param), nil }, nil
+}
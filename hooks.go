@@ -0,0 +1,34 @@
+package conform
+
+import (
+	"reflect"
+	"sync"
+)
+
+var typeHooks = struct {
+	sync.RWMutex
+	m map[reflect.Type][]func(interface{}) error
+}{m: map[reflect.Type][]func(interface{}) error{}}
+
+// RegisterTypeHook adds a hook that runs after the tags of a struct of type T have been applied, wherever it's
+// found in the value being conformed, such as to recompute fields derived from those that were conformed. Hooks
+// for the same type run in the order they were registered, and their errors are always returned.
+func RegisterTypeHook[T any](hook func(*T) error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	typeHooks.Lock()
+	typeHooks.m[t] = append(typeHooks.m[t], func(v interface{}) error { return hook(v.(*T)) })
+	typeHooks.Unlock()
+}
+
+// runTypeHooks runs the hooks for the type ptr points to
+func runTypeHooks(ptr interface{}) error {
+	typeHooks.RLock()
+	hooks := typeHooks.m[reflect.TypeOf(ptr).Elem()]
+	typeHooks.RUnlock()
+	for _, hook := range hooks {
+		if err := hook(ptr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
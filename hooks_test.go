@@ -0,0 +1,65 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hookedPerson struct {
+	First       string `conform:"name"`
+	Last        string `conform:"name"`
+	DisplayName string
+	SearchKey   string
+}
+
+type hookedRefusal struct {
+	Reason string `conform:"trim"`
+}
+
+func (t *testSuite) TestTypeHooks() {
+	assert := assert.New(t.T())
+
+	RegisterTypeHook(func(p *hookedPerson) error {
+		p.DisplayName = p.First + " " + p.Last
+		return nil
+	})
+	RegisterTypeHook(func(p *hookedPerson) error {
+		p.SearchKey = p.DisplayName + "!"
+		return nil
+	})
+
+	var team struct {
+		Lead    hookedPerson
+		Members []*hookedPerson
+		ByRole  map[string]hookedPerson
+	}
+	team.Lead = hookedPerson{First: " ada ", Last: "lovelace"}
+	team.Members = []*hookedPerson{{First: "alan", Last: "turing "}}
+	team.ByRole = map[string]hookedPerson{"cto": {First: "grace", Last: "hopper"}}
+
+	assert.NoError(Strings(&team))
+	assert.Equal("Ada Lovelace", team.Lead.DisplayName, "Hooks should run after tags")
+	assert.Equal("Ada Lovelace!", team.Lead.SearchKey, "Hooks should run in the order they were registered")
+	assert.Equal("Alan Turing!", team.Members[0].SearchKey)
+	assert.Equal("Grace Hopper!", team.ByRole["cto"].SearchKey)
+
+	p := hookedPerson{First: "charles", Last: "babbage"}
+	assert.NoError(Strings(&p))
+	assert.Equal("Charles Babbage", p.DisplayName, "Hooks should run on the value passed to Strings")
+}
+
+func (t *testSuite) TestTypeHookError() {
+	assert := assert.New(t.T())
+
+	RegisterTypeHook(func(r *hookedRefusal) error {
+		return errors.New("refused")
+	})
+
+	var s struct {
+		Refusal hookedRefusal
+	}
+	assert.EqualError(Strings(&s), "Refusal: refused", "Hook errors should be returned without Strict")
+}
@@ -0,0 +1,124 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// irregulars maps singular nouns to plurals the rules get wrong, and plurals back to singulars. Nouns with the same
+// singular and plural, such as "sheep", are left as they are.
+var irregulars = struct {
+	sync.RWMutex
+	plurals   map[string]string
+	singulars map[string]string
+}{plurals: map[string]string{}, singulars: map[string]string{}}
+
+func init() {
+	RegisterIrregularPlurals(map[string]string{
+		"person": "people", "man": "men", "woman": "women", "child": "children", "tooth": "teeth",
+		"foot": "feet", "mouse": "mice", "goose": "geese", "ox": "oxen", "cactus": "cacti",
+		"criterion": "criteria", "phenomenon": "phenomena", "matrix": "matrices", "vertex": "vertices",
+		"index": "indices", "quiz": "quizzes", "leaf": "leaves", "knife": "knives", "life": "lives",
+		"wife": "wives", "half": "halves", "wolf": "wolves", "shelf": "shelves", "thief": "thieves",
+		"loaf": "loaves", "calf": "calves", "potato": "potatoes", "tomato": "tomatoes", "hero": "heroes",
+		"echo": "echoes", "veto": "vetoes", "movie": "movies", "cookie": "cookies", "pie": "pies",
+		"tie": "ties", "lie": "lies", "zombie": "zombies", "crisis": "crises", "thesis": "theses",
+		"basis": "bases", "diagnosis": "diagnoses", "hypothesis": "hypotheses", "axis": "axes",
+		"sheep": "sheep", "fish": "fish", "deer": "deer", "series": "series", "species": "species",
+		"news": "news", "money": "money", "rice": "rice", "information": "information",
+		"equipment": "equipment", "software": "software", "hardware": "hardware", "feedback": "feedback",
+		"data": "data", "media": "media", "bus": "buses", "virus": "viruses", "status": "statuses",
+		"campus": "campuses", "bonus": "bonuses", "census": "censuses", "circus": "circuses", "focus": "focuses",
+		"cache": "caches", "niche": "niches", "ache": "aches", "headache": "headaches",
+	})
+}
+
+// RegisterIrregularPlurals adds nouns the singular and plural tags would get wrong, mapping singulars to
+// plurals, e.g. {"alumnus": "alumni"}. Map a noun to itself to leave it as it is.
+func RegisterIrregularPlurals(plurals map[string]string) {
+	irregulars.Lock()
+	defer irregulars.Unlock()
+	for singular, plural := range plurals {
+		singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+		irregulars.plurals[singular] = plural
+		irregulars.singulars[plural] = singular
+	}
+}
+
+// inflect applies f to the last word of s, so "Hot Dogs" becomes "Hot Dog", keeping the word's case
+func inflect(s string, f func(string) string) string {
+	end := strings.LastIndexFunc(s, unicode.IsLetter)
+	if end == -1 {
+		return s
+	}
+	_, size := utf8.DecodeRuneInString(s[end:])
+	end += size
+	start := strings.LastIndexFunc(s[:end], func(r rune) bool { return !unicode.IsLetter(r) })
+	if start == -1 {
+		start = 0
+	} else {
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	word := s[start:end]
+	out := f(strings.ToLower(word))
+	if first, _ := utf8.DecodeRuneInString(word); len(word) > 1 && word == strings.ToUpper(word) {
+		out = strings.ToUpper(out)
+	} else if unicode.IsUpper(first) {
+		out = ucFirst(out)
+	}
+	return s[:start] + out + s[end:]
+}
+
+// singular returns the singular of a lowercase English noun
+func singular(w string) string {
+	irregulars.RLock()
+	s, ok := irregulars.singulars[w]
+	_, same := irregulars.plurals[w]
+	irregulars.RUnlock()
+	if ok {
+		return s
+	}
+	if same {
+		return w
+	}
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "yses"):
+		return w[:len(w)-2] + "is"
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "xes"), strings.HasSuffix(w, "zzes"),
+		strings.HasSuffix(w, "ches"), strings.HasSuffix(w, "shes"):
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "ss"), strings.HasSuffix(w, "us"), strings.HasSuffix(w, "is"):
+		return w
+	case strings.HasSuffix(w, "s") && len(w) > 1:
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// plural returns the plural of a lowercase English noun. Nouns already plural are left as they are.
+func plural(w string) string {
+	w = singular(w)
+	irregulars.RLock()
+	p, ok := irregulars.plurals[w]
+	irregulars.RUnlock()
+	if ok {
+		return p
+	}
+	switch {
+	case strings.HasSuffix(w, "y") && len(w) > 1 && !strings.ContainsRune("aeiou", rune(w[len(w)-2])):
+		return w[:len(w)-1] + "ies"
+	case strings.HasSuffix(w, "sis"):
+		return w[:len(w)-2] + "es"
+	case strings.HasSuffix(w, "s"), strings.HasSuffix(w, "x"), strings.HasSuffix(w, "z"),
+		strings.HasSuffix(w, "ch"), strings.HasSuffix(w, "sh"):
+		return w + "es"
+	}
+	return w + "s"
+}
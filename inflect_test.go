@@ -0,0 +1,66 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestInflect() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string][2]string{
+		"dog":       {"dog", "dogs"},
+		"dogs":      {"dog", "dogs"},
+		"city":      {"city", "cities"},
+		"Cities":    {"City", "Cities"},
+		"day":       {"day", "days"},
+		"box":       {"box", "boxes"},
+		"churches":  {"church", "churches"},
+		"glass":     {"glass", "glasses"},
+		"houses":    {"house", "houses"},
+		"bus":       {"bus", "buses"},
+		"analysis":  {"analysis", "analyses"},
+		"analyses":  {"analysis", "analyses"},
+		"people":    {"person", "people"},
+		"knife":     {"knife", "knives"},
+		"sheep":     {"sheep", "sheep"},
+		"movies":    {"movie", "movies"},
+		"hot dogs":  {"hot dog", "hot dogs"},
+		"Hot Dogs!": {"Hot Dog!", "Hot Dogs!"},
+		"USER":      {"USER", "USERS"},
+		"café":      {"café", "cafés"},
+		"123":       {"123", "123"},
+		"":          {"", ""},
+	} {
+		assert.Equal(want[0], inflect(in, singular), "singular %q", in)
+		assert.Equal(want[1], inflect(in, plural), "plural %q", in)
+	}
+
+	RegisterIrregularPlurals(map[string]string{"Alumnus": "Alumni"})
+	assert.Equal("alumni", inflect("alumnus", plural))
+	assert.Equal("alumnus", inflect("alumni", singular))
+
+	// registering while nouns are being inflected is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterIrregularPlurals(map[string]string{"octopus": "octopodes"})
+	}()
+	inflect("octopus", plural)
+	wg.Wait()
+	assert.Equal("octopodes", inflect("octopus", plural))
+
+	var s struct {
+		Category string   `conform:"trim,lower,singular"`
+		Tags     []string `conform:"plural"`
+	}
+	s.Category = " Dogs "
+	s.Tags = []string{"cat", "mice"}
+	assert.NoError(Strings(&s))
+	assert.Equal("dog", s.Category)
+	assert.Equal([]string{"cats", "mice"}, s.Tags)
+}
@@ -0,0 +1,68 @@
+package conform
+
+import (
+	"strings"
+	"sync"
+)
+
+// Interner makes equal strings share memory, so values repeated across many structs, such as country codes or
+// enum-like strings, are only held once after they're conformed. It holds at most a fixed number of strings,
+// dropping one at random to make room for another, and is safe for concurrent use.
+type Interner struct {
+	mu      sync.RWMutex
+	max     int
+	strings map[string]string
+}
+
+// NewInterner returns an Interner holding at most maxEntries strings
+func NewInterner(maxEntries int) *Interner {
+	return &Interner{max: maxEntries, strings: make(map[string]string)}
+}
+
+// Intern returns a string equal to s, sharing memory with those returned before it when it can
+func (in *Interner) Intern(s string) string {
+	if s == "" || in.max <= 0 {
+		return s
+	}
+	in.mu.RLock()
+	interned, ok := in.strings[s]
+	in.mu.RUnlock()
+	if ok {
+		return interned
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if interned, ok := in.strings[s]; ok {
+		return interned
+	}
+	if len(in.strings) >= in.max {
+		dropOne(in.strings)
+	}
+	// a copy, so a short value cut from a long string, such as a decoded request body, doesn't keep it in memory
+	s = strings.Clone(s)
+	in.strings[s] = s
+	return s
+}
+
+// interner is the Interner Strings uses, set by WithInterning
+var interner *Interner
+
+// WithInterning makes Strings intern the values it conforms, keeping up to maxEntries of them. 0 turns
+// interning off. Call it before conforming anything, as when setting Strict.
+func WithInterning(maxEntries int) {
+	if maxEntries <= 0 {
+		interner = nil
+		return
+	}
+	interner = NewInterner(maxEntries)
+}
+
+// dropOne deletes a random entry from m, to make room in a bounded cache. Map iteration order is random, so the
+// first key ranged over will do.
+func dropOne[K comparable, V any](m map[K]V) {
+	for k := range m {
+		delete(m, k)
+		return
+	}
+}
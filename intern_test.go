@@ -0,0 +1,66 @@
+package conform
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stringData returns the address of the bytes s holds
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func (t *testSuite) TestInterner() {
+	assert := assert.New(t.T())
+
+	in := NewInterner(2)
+	a := in.Intern(strings.Repeat("G", 1) + "B")
+	b := in.Intern(strings.Repeat("G", 1) + "B")
+	assert.Equal("GB", b)
+	assert.Equal(stringData(a), stringData(b), "Equal strings should share memory")
+
+	in.Intern("US")
+	in.Intern("FR")
+	assert.Len(in.strings, 2, "The interner should hold no more than its maximum")
+
+	body := strings.Repeat("x", 1<<10) + "DE"
+	de := in.Intern(body[len(body)-2:])
+	assert.NotEqual(stringData(body[len(body)-2:]), stringData(de),
+		"Interned strings shouldn't share memory with the strings they were cut from")
+}
+
+func (t *testSuite) TestWithInterning() {
+	assert := assert.New(t.T())
+
+	WithInterning(10)
+	defer WithInterning(0)
+
+	type address struct {
+		Country string `conform:"trim,upper"`
+	}
+	addresses := []address{{" gb"}, {"GB "}, {"GB"}}
+	for i := range addresses {
+		assert.NoError(Strings(&addresses[i]))
+	}
+	for _, a := range addresses {
+		assert.Equal("GB", a.Country)
+		assert.Equal(stringData(addresses[0].Country), stringData(a.Country))
+	}
+
+	var s struct {
+		Codes []string `conform:"upper"`
+	}
+	s.Codes = []string{strings.ToUpper("gb")}
+	assert.NoError(Strings(&s))
+	assert.Equal(stringData(addresses[0].Country), stringData(s.Codes[0]), "Unchanged elements should be interned")
+
+	gb := "GB"
+	ptrs := struct {
+		Codes []*string `conform:"upper"`
+	}{[]*string{&gb}}
+	assert.NoError(Strings(&ptrs))
+	assert.Same(&gb, ptrs.Codes[0], "Unchanged pointers should be left alone")
+}
@@ -0,0 +1,116 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stripCode removes the spaces and hyphens that break up ISBNs and barcodes, and uppercases "x" check digits
+func stripCode(s string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "‐", "", "‑", "").Replace(strings.TrimSpace(s)))
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// gtinValid checks the check digit of an EAN, UPC or ISBN-13, which weights digits 1, 3, 1, ... from the right
+func gtinValid(s string) bool {
+	return allDigits(s) && gtinSum(s)%10 == 0
+}
+
+func gtinSum(s string) int {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		d := int(s[len(s)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return sum
+}
+
+// gtinCheckDigit returns the check digit to append to s
+func gtinCheckDigit(s string) byte {
+	return byte('0' + (10-gtinSum(s+"0")%10)%10)
+}
+
+// isbn10Valid checks the check digit of an ISBN-10, where "X" stands for 10
+func isbn10Valid(s string) bool {
+	if len(s) != 10 || !allDigits(s[:9]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(s[i]-'0')
+	}
+	switch c := s[9]; {
+	case c == 'X':
+		sum += 10
+	case c >= '0' && c <= '9':
+		sum += int(c - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// isbn strips an ISBN to its digits and check digit. With to13, ISBN-10s become ISBN-13s.
+func isbn(s string, to13 bool) (string, error) {
+	c := stripCode(strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(s)), "ISBN"))
+	c = strings.TrimPrefix(c, ":")
+	switch {
+	case len(c) == 13 && gtinValid(c) && (strings.HasPrefix(c, "978") || strings.HasPrefix(c, "979")):
+		return c, nil
+	case isbn10Valid(c):
+		if to13 {
+			c = "978" + c[:9]
+			return c + string(gtinCheckDigit(c)), nil
+		}
+		return c, nil
+	}
+	return s, fmt.Errorf("%q is not an ISBN", s)
+}
+
+// issn formats an ISSN as two groups of four, e.g. "0317-8471", checking its check digit
+func issn(s string) (string, error) {
+	c := stripCode(strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(s)), "ISSN"))
+	if len(c) == 8 && allDigits(c[:7]) {
+		sum := 0
+		for i := 0; i < 7; i++ {
+			sum += (8 - i) * int(c[i]-'0')
+		}
+		check := "0123456789X"[(11-sum%11)%11]
+		if c[7] == check {
+			return c[:4] + "-" + c[4:], nil
+		}
+	}
+	return s, fmt.Errorf("%q is not an ISSN", s)
+}
+
+// ean strips an EAN, UPC or other GTIN barcode number to its digits, checking its check digit
+func ean(s string) (string, error) {
+	c := stripCode(s)
+	switch len(c) {
+	case 8, 12, 13, 14:
+		if gtinValid(c) {
+			return c, nil
+		}
+	}
+	return s, fmt.Errorf("%q is not an EAN", s)
+}
+
+func isbnDirective(param string) (transform, error) {
+	if param != "" && param != "13" {
+		return nil, fmt.Errorf("%q is not \"13\"", param)
+	}
+	return func(s string) (string, error) { return isbn(s, param == "13") }, nil
+}
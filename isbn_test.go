@@ -0,0 +1,75 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestISBN() {
+	assert := assert.New(t.T())
+
+	for _, c := range []struct {
+		in   string
+		to13 bool
+		want string
+	}{
+		{"0-306-40615-2", false, "0306406152"},
+		{"ISBN 0-306-40615-2", true, "9780306406157"},
+		{"978-0-306-40615-7", false, "9780306406157"},
+		{"978 0 306 40615 7", true, "9780306406157"},
+		{"0-8044-2957-x", false, "080442957X"},
+		{"0-8044-2957-x", true, "9780804429573"},
+	} {
+		out, err := isbn(c.in, c.to13)
+		assert.NoError(err, c.in)
+		assert.Equal(c.want, out, c.in)
+	}
+
+	for _, in := range []string{"0-306-40615-3", "978-0-306-40615-8", "4006381333931", "12345", ""} {
+		out, err := isbn(in, false)
+		assert.Error(err, in)
+		assert.Equal(in, out)
+	}
+
+	_, err := Compile("isbn=10")
+	assert.EqualError(err, `isbn=10: "10" is not "13"`)
+}
+
+func (t *testSuite) TestISSN() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"03178471":       "0317-8471",
+		"ISSN 2049-3630": "2049-3630",
+		"0000-006x":      "0000-006X",
+	} {
+		out, err := issn(in)
+		assert.NoError(err, in)
+		assert.Equal(want, out, in)
+	}
+
+	_, err := issn("0317-8472")
+	assert.EqualError(err, `"0317-8472" is not an ISSN`)
+}
+
+func (t *testSuite) TestEAN() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"4006381333931":    "4006381333931",
+		"400 6381 33393 1": "4006381333931",
+		"036000291452":     "036000291452",
+		"9638-5074":        "96385074",
+		"10614141000415":   "10614141000415",
+	} {
+		out, err := ean(in)
+		assert.NoError(err, in)
+		assert.Equal(want, out, in)
+	}
+
+	for _, in := range []string{"4006381333932", "40063813339", "abc"} {
+		_, err := ean(in)
+		assert.Error(err, in)
+	}
+}
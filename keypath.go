@@ -0,0 +1,37 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"regexp"
+	"strings"
+)
+
+// keyPath is a key path written with dots, "a.b.c", brackets, "a[b][c]", or a mix of the two. Segments can't
+// be empty or contain dots or brackets, so paths such as "a..b", "a[]" or "a[b.c]" are left alone.
+var keyPath = regexp.MustCompile(`^[^.\[\]]+(?:\.[^.\[\]]+|\[[^.\[\]]+\])*$`)
+
+func keySegments(s string) ([]string, bool) {
+	if !keyPath.MatchString(s) {
+		return nil, false
+	}
+	return strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == '[' || r == ']' }), true
+}
+
+// keyFlatten rewrites a bracketed key path, "a[b][c]", with dots, "a.b.c"
+func keyFlatten(s string) string {
+	segments, ok := keySegments(s)
+	if !ok {
+		return s
+	}
+	return strings.Join(segments, ".")
+}
+
+// keyBrackets rewrites a dotted key path, "a.b.c", with brackets, "a[b][c]"
+func keyBrackets(s string) string {
+	segments, ok := keySegments(s)
+	if !ok || len(segments) == 1 {
+		return s
+	}
+	return segments[0] + "[" + strings.Join(segments[1:], "][") + "]"
+}
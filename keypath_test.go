@@ -0,0 +1,30 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestKeyPaths() {
+	assert := assert.New(t.T())
+
+	for _, c := range []struct{ brackets, dots string }{
+		{"a[b][c]", "a.b.c"},
+		{"user[addresses][0][city]", "user.addresses.0.city"},
+		{"meta", "meta"},
+	} {
+		assert.Equal(c.dots, keyFlatten(c.brackets), c.brackets)
+		assert.Equal(c.brackets, keyBrackets(c.dots), c.dots)
+		assert.Equal(c.dots, keyFlatten(c.dots), "Dotted paths should be left as they are")
+		assert.Equal(c.brackets, keyBrackets(c.brackets), "Bracketed paths should be left as they are")
+	}
+
+	assert.Equal("a.b.c", keyFlatten("a[b].c"), "Mixed paths should be flattened")
+	assert.Equal("a[b][c]", keyBrackets("a.b[c]"))
+
+	for _, in := range []string{"a..b", "a[]", "a[b.c]", ".a", "a.", "[a]", "a[b]c", "a]b", ""} {
+		assert.Equal(in, keyFlatten(in), in)
+		assert.Equal(in, keyBrackets(in), in)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// langTag canonicalises a BCP 47 language tag, leaving values that don't parse as they are
+func langTag(s string) string {
+	tag, err := language.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return s
+	}
+	return tag.String()
+}
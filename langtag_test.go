@@ -0,0 +1,29 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestLangTag() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Underscore string `conform:"langtag"`
+		Upper      string `conform:"langtag"`
+		Script     string `conform:"langtag"`
+		Invalid    string `conform:"langtag"`
+	}
+
+	s.Underscore = "en_us"
+	s.Upper = " EN "
+	s.Script = "ZH-HANT-tw"
+	s.Invalid = "not a language"
+	Strings(&s)
+
+	assert.Equal("en-US", s.Underscore)
+	assert.Equal("en", s.Upper)
+	assert.Equal("zh-Hant-TW", s.Script)
+	assert.Equal("not a language", s.Invalid, "Invalid tags should be left alone")
+}
@@ -0,0 +1,124 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// coordinate matches a single coordinate in decimal degrees or degrees, minutes and seconds, with an optional
+// hemisphere before or after it, e.g. "-79.982", "40° 26′ 46″ N" or "W 79 58' 56\"". It's matched against the
+// coordinate in upper case, so units such as "deg" are too.
+var coordinate = regexp.MustCompile(`^([NSEW])?\s*(-?\d+(?:\.\d+)?)\s*(?:°|º|D|DEG)?\s*` +
+	`(?:(\d+(?:\.\d+)?)\s*(?:′|'|’|M)\s*)?(?:(\d+(?:\.\d+)?)\s*(?:″|"|”|''|S)\s*)?([NSEW])?$`)
+
+// parseCoordinate returns a coordinate in decimal degrees, and its hemisphere if one was given
+func parseCoordinate(s string) (float64, byte, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	idx := coordinate.FindStringSubmatchIndex(s)
+	if idx == nil {
+		return 0, 0, false
+	}
+	// a number split in two, such as "26′" read as 2° 6′, isn't a match
+	for _, end := range []int{idx[5], idx[7]} {
+		if end > 0 && end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+			return 0, 0, false
+		}
+	}
+	m := make([]string, len(idx)/2)
+	for i := range m {
+		if idx[2*i] >= 0 {
+			m[i] = s[idx[2*i]:idx[2*i+1]]
+		}
+	}
+	if m[1] != "" && m[5] != "" {
+		return 0, 0, false
+	}
+	deg, _ := strconv.ParseFloat(m[2], 64)
+	var min, sec float64
+	if m[3] != "" {
+		min, _ = strconv.ParseFloat(m[3], 64)
+	}
+	if m[4] != "" {
+		sec, _ = strconv.ParseFloat(m[4], 64)
+	}
+	if min >= 60 || sec >= 60 || (m[3] != "" || m[4] != "") && deg != math.Trunc(deg) {
+		return 0, 0, false
+	}
+
+	v := math.Abs(deg) + min/60 + sec/3600
+	hemisphere := (m[1] + m[5] + " ")[0]
+	if strings.HasPrefix(m[2], "-") || hemisphere == 'S' || hemisphere == 'W' {
+		v = -v
+	}
+	if hemisphere == ' ' {
+		hemisphere = 0
+	}
+	if math.Abs(v) > 180 || (hemisphere == 'N' || hemisphere == 'S') && math.Abs(v) > 90 {
+		return 0, 0, false
+	}
+	return v, hemisphere, true
+}
+
+// latLon rewrites a coordinate, or a latitude and longitude pair, in decimal degrees with places decimal places.
+// Pairs are separated by a comma or whitespace, and are written latitude first, separated by a comma.
+func latLon(s string, places int) (string, error) {
+	format := func(v float64) string {
+		p := math.Pow(10, float64(places))
+		if v = math.Round(v*p) / p; v == 0 {
+			// no "-0"
+			v = 0
+		}
+		return strconv.FormatFloat(v, 'f', places, 64)
+	}
+
+	var halves [][2]string
+	if parts := strings.Split(s, ","); len(parts) == 2 {
+		halves = append(halves, [2]string{parts[0], parts[1]})
+	} else if len(parts) == 1 {
+		if v, _, ok := parseCoordinate(s); ok {
+			return format(v), nil
+		}
+		fields := strings.Fields(s)
+		for i := 1; i < len(fields); i++ {
+			halves = append(halves, [2]string{strings.Join(fields[:i], " "), strings.Join(fields[i:], " ")})
+		}
+	}
+
+	for _, h := range halves {
+		lat, latHemisphere, ok := parseCoordinate(h[0])
+		if !ok {
+			continue
+		}
+		lon, lonHemisphere, ok := parseCoordinate(h[1])
+		if !ok {
+			continue
+		}
+		if latHemisphere == 'E' || latHemisphere == 'W' || lonHemisphere == 'N' || lonHemisphere == 'S' {
+			lat, lon = lon, lat
+			latHemisphere, lonHemisphere = lonHemisphere, latHemisphere
+		}
+		if latHemisphere == 'E' || latHemisphere == 'W' || lonHemisphere == 'N' || lonHemisphere == 'S' ||
+			math.Abs(lat) > 90 {
+			break
+		}
+		return format(lat) + "," + format(lon), nil
+	}
+	return s, fmt.Errorf("%q is not a latitude or longitude", s)
+}
+
+func latLonDirective(param string) (transform, error) {
+	places := 6
+	if param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 || n > 15 {
+			return nil, fmt.Errorf("%q is not a whole number from 0 to 15", param)
+		}
+		places = n
+	}
+	return func(s string) (string, error) { return latLon(s, places) }, nil
+}
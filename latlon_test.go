@@ -0,0 +1,48 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestLatLon() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"40.446,-79.982":               "40.446000,-79.982000",
+		" 40.446 , -79.982 ":           "40.446000,-79.982000",
+		"40.446 -79.982":               "40.446000,-79.982000",
+		"40° 26′ 46″ N":                "40.446111",
+		"40d 26m 46s N":                "40.446111",
+		"40deg 26m 46s N":              "40.446111",
+		"40° 26′ 46″ N 79° 58′ 56″ W":  "40.446111,-79.982222",
+		`40°26'46"N, 79°58'56"W`:       "40.446111,-79.982222",
+		"79° 58′ 56″ W, 40° 26′ 46″ N": "40.446111,-79.982222",
+		"s 33 51.5' , e 151 12.5'":     "-33.858333,151.208333",
+		"-0.0000001":                   "0.000000",
+		"-79.982":                      "-79.982000",
+	} {
+		out, err := latLon(in, 6)
+		assert.NoError(err, in)
+		assert.Equal(want, out, in)
+	}
+
+	for _, in := range []string{
+		"", "north", "95, 10", "10, 190", "40° 61′ N", "40.5° 26′ N", "N 40 S", "10 E, 20 W", "1,2,3",
+	} {
+		out, err := latLon(in, 6)
+		assert.Error(err, in)
+		assert.Equal(in, out, in)
+	}
+
+	var s struct {
+		Location string `conform:"latlon=3"`
+	}
+	s.Location = "40° 26′ 46″ N, 79° 58′ 56″ W"
+	assert.NoError(Strings(&s))
+	assert.Equal("40.446,-79.982", s.Location)
+
+	_, err := Compile("latlon=x")
+	assert.EqualError(err, `latlon=x: "x" is not a whole number from 0 to 15`)
+}
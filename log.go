@@ -0,0 +1,35 @@
+package conform
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logger is the Logger Strings uses, set by WithLogger
+var logger *slog.Logger
+
+// WithLogger makes Strings log each field it changes to l at debug level, with its path, the tags that changed
+// it, and its value before and after. Values of fields with "nolog" in their chain, such as passwords, are left
+// out. nil turns logging off. Call it before conforming anything, as when setting Strict.
+func WithLogger(l *slog.Logger) {
+	logger = l
+}
+
+// defaultOptions are the options Strings and the functions like it use, from the package-level settings
+func defaultOptions() Options {
+	return Options{Strict: Strict, Interner: interner, Logger: logger, Metrics: metrics}
+}
+
+// logChange logs a field the walker changed. fired lists the tags in its chain that changed it.
+func (w walker) logChange(before, after string, fired []string, nolog bool) {
+	if nolog {
+		w.opts.Logger.Debug("conformed field", "path", w.path, "tags", fired)
+		return
+	}
+	w.opts.Logger.Debug("conformed field", "path", w.path, "tags", fired, "before", before, "after", after)
+}
+
+// loggingEnabled reports whether l logs debug messages, so the work of logging can be skipped when it doesn't
+func loggingEnabled(l *slog.Logger) bool {
+	return l != nil && l.Enabled(context.Background(), slog.LevelDebug)
+}
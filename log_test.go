@@ -0,0 +1,40 @@
+package conform
+
+import (
+	"bytes"
+	"log/slog"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestLogger() {
+	assert := assert.New(t.T())
+
+	type account struct {
+		Email    string   `conform:"trim,lower"`
+		Password string   `conform:"trim,nolog"`
+		Tags     []string `conform:"trim"`
+		Name     string   `conform:"trim"`
+	}
+
+	var b bytes.Buffer
+	WithLogger(slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer WithLogger(nil)
+
+	a := account{Email: " Ann@X.io", Password: " hunter2 ", Tags: []string{"ok", " b "}, Name: "Ann"}
+	assert.NoError(Strings(&a))
+	out := b.String()
+	assert.Contains(out, `msg="conformed field" path=Email tags="[trim lower]" before=" Ann@X.io" after=ann@x.io`)
+	assert.Contains(out, `msg="conformed field" path=Password tags=[trim]`)
+	assert.NotContains(out, "hunter2", "Values of nolog fields shouldn't be logged")
+	assert.Contains(out, `path=Tags[1] tags=[trim] before=" b " after=b`)
+	assert.NotContains(out, "path=Name", "Unchanged fields shouldn't be logged")
+	assert.NotContains(out, "path=Tags[0]")
+	assert.Equal("hunter2", a.Password, "nolog shouldn't change the value")
+
+	b.Reset()
+	WithLogger(slog.New(slog.NewTextHandler(&b, nil)))
+	a.Email = " x "
+	assert.NoError(Strings(&a))
+	assert.Empty(b.String(), "Nothing should be logged when debug is off")
+}
@@ -0,0 +1,33 @@
+//go:build !conform_minimal
+
+package conform
+
+import "sync"
+
+// lookupTables holds the tables added with RegisterLookup, keyed by name
+var lookupTables = struct {
+	sync.RWMutex
+	m map[string]map[string]string
+}{m: map[string]map[string]string{}}
+
+// RegisterLookup registers a table of canonical values for use with the "lookup=name" tag.
+// Keys are matched ignoring case, dots and repeated whitespace; values not in the table are left as they are.
+func RegisterLookup(name string, table map[string]string) {
+	t := make(map[string]string, len(table))
+	for k, v := range table {
+		t[lookupKey(k)] = v
+	}
+	lookupTables.Lock()
+	lookupTables.m[name] = t
+	lookupTables.Unlock()
+}
+
+func lookup(s, name string) string {
+	lookupTables.RLock()
+	t := lookupTables.m[name]
+	lookupTables.RUnlock()
+	if v, ok := t[lookupKey(s)]; ok {
+		return v
+	}
+	return s
+}
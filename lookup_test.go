@@ -0,0 +1,73 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestUSState() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Name       string `conform:"usstate"`
+		Code       string `conform:"usstate"`
+		Misspelled string `conform:"usstate"`
+		Abbrev     string `conform:"usstate"`
+		Unknown    string `conform:"usstate"`
+	}
+
+	s.Name = "new  york"
+	s.Code = "tx"
+	s.Misspelled = "Massachusets"
+	s.Abbrev = "Calif."
+	s.Unknown = "Ontario"
+	Strings(&s)
+
+	assert.Equal("NY", s.Name)
+	assert.Equal("TX", s.Code)
+	assert.Equal("MA", s.Misspelled)
+	assert.Equal("CA", s.Abbrev)
+	assert.Equal("Ontario", s.Unknown, "Unknown states should be left alone")
+}
+
+func (t *testSuite) TestLookup() {
+	assert := assert.New(t.T())
+
+	RegisterLookup("size", map[string]string{
+		"Small": "S",
+		"sm":    "S",
+		"large": "L",
+	})
+
+	var s struct {
+		Small   string `conform:"trim,lookup=size"`
+		Large   string `conform:"lookup=size"`
+		Unknown string `conform:"lookup=size"`
+		NoTable string `conform:"lookup=nope"`
+	}
+
+	s.Small = " SMALL "
+	s.Large = "Large"
+	s.Unknown = "medium"
+	s.NoTable = "small"
+	Strings(&s)
+
+	assert.Equal("S", s.Small)
+	assert.Equal("L", s.Large)
+	assert.Equal("medium", s.Unknown, "Values not in the table should be left alone")
+	assert.Equal("small", s.NoTable, "Unregistered tables should leave the value alone")
+
+	// registering while fields are being conformed is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterLookup("colour", map[string]string{"grey": "gray"})
+	}()
+	lookup("grey", "colour")
+	wg.Wait()
+	assert.Equal("gray", lookup("Grey", "colour"))
+}
@@ -0,0 +1,104 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"regexp"
+	"strings"
+)
+
+var md = map[string]*regexp.Regexp{
+	"fence":            regexp.MustCompile("^\\s*(```|~~~)"),
+	"atx":              regexp.MustCompile(`^\s{0,3}(#{1,6})\s*(.*?)\s*#*\s*$`),
+	"setext":           regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`),
+	"rule":             regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`),
+	"quote":            regexp.MustCompile(`^\s{0,3}>\s?`),
+	"bullet":           regexp.MustCompile(`^(\s*)[-*+]\s+`),
+	"ordered":          regexp.MustCompile(`^(\s*)\d+[.)]\s+`),
+	"refdef":           regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*\S+.*$`),
+	"image":            regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`),
+	"link":             regexp.MustCompile(`\[([^\]]+)\](\([^)]*\)|\[[^\]]*\])`),
+	"autolink":         regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`),
+	"code":             regexp.MustCompile("`+([^`]+)`+"),
+	"strong":           regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`),
+	"emphasis":         regexp.MustCompile(`(^|[^\pL\pN*_])[*_](\S(?:[^*_]*?\S)?)[*_]($|[^\pL\pN*_])`),
+	"strike":           regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`),
+	"underscoreStrong": regexp.MustCompile(`__(\S(?:.*?\S)?)__`),
+	"starEmphasis":     regexp.MustCompile(`(^|[^\pL\pN*])\*([^*\s](?:[^*]*?[^*\s])?)\*($|[^\pL\pN*])`),
+}
+
+// noMarkdown renders markdown as plain text, keeping the text of links, images and code
+func noMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	for _, line := range lines {
+		if md["fence"].MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+		if md["setext"].MatchString(line) && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" ||
+			md["rule"].MatchString(line) || md["refdef"].MatchString(line) {
+			continue
+		}
+		line = md["quote"].ReplaceAllString(line, "")
+		if m := md["atx"].FindStringSubmatch(line); m != nil {
+			line = m[2]
+		}
+		line = md["bullet"].ReplaceAllString(line, "$1")
+		line = md["ordered"].ReplaceAllString(line, "$1")
+		line = md["image"].ReplaceAllString(line, "$1")
+		line = md["link"].ReplaceAllString(line, "$1")
+		line = md["autolink"].ReplaceAllString(line, "$1")
+		line = md["code"].ReplaceAllString(line, "$1")
+		line = md["strong"].ReplaceAllString(line, "$2")
+		line = md["strike"].ReplaceAllString(line, "$1")
+		line = md["emphasis"].ReplaceAllString(line, "$1$2$3")
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// mdNormalize rewrites headings as ATX ("# Heading"), strong emphasis as "**", emphasis as "_" and bullets as "-".
+// Fenced code blocks are left alone.
+func mdNormalize(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	for _, line := range lines {
+		if md["fence"].MatchString(line) {
+			inFence = !inFence
+			out = append(out, line)
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+		if m := md["setext"].FindStringSubmatch(line); m != nil && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" &&
+			!strings.HasPrefix(out[len(out)-1], "#") {
+			level := "#"
+			if strings.HasPrefix(m[1], "-") {
+				level = "##"
+			}
+			out[len(out)-1] = level + " " + strings.TrimSpace(out[len(out)-1])
+			continue
+		}
+		if md["rule"].MatchString(line) {
+			out = append(out, "---")
+			continue
+		}
+		if m := md["atx"].FindStringSubmatch(line); m != nil && m[2] != "" {
+			line = m[1] + " " + m[2]
+		}
+		line = md["bullet"].ReplaceAllString(line, "$1- ")
+		line = md["underscoreStrong"].ReplaceAllString(line, "**$1**")
+		line = md["starEmphasis"].ReplaceAllString(line, "${1}_${2}_$3")
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
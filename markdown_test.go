@@ -0,0 +1,59 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestNoMarkdown() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Body string `conform:"nomarkdown"`
+	}
+
+	s.Body = "# Hello *world*\n\n" +
+		"Some **bold**, __strong__, _em_ and ~~gone~~ text with `code`.\n" +
+		"> quoted [link](https://example.com) and ![an image](img.png)\n\n" +
+		"- one\n* two\n1. three\n\n" +
+		"---\n" +
+		"Setext\n======\n" +
+		"```go\nfmt.Println(\"*x*\")\n```\n" +
+		"snake_case_name stays, see <https://example.com>\n" +
+		"[ref]: https://example.com"
+	Strings(&s)
+
+	assert.Equal("Hello world\n\n"+
+		"Some bold, strong, em and gone text with code.\n"+
+		"quoted link and an image\n\n"+
+		"one\ntwo\nthree\n\n"+
+		"Setext\n"+
+		"fmt.Println(\"*x*\")\n"+
+		"snake_case_name stays, see https://example.com", s.Body)
+}
+
+func (t *testSuite) TestMarkdownNormalize() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Body string `conform:"md_normalize"`
+	}
+
+	s.Body = "Title\n=====\n" +
+		"Sub\n---\n" +
+		"###Closed ###\n" +
+		"Some __strong__ and *em* text, 2 * 3 * 4 and snake_case_name.\n" +
+		"* one\n+ two\n- three\n" +
+		"***\n" +
+		"```\n__keep__ *this*\n```"
+	Strings(&s)
+
+	assert.Equal("# Title\n"+
+		"## Sub\n"+
+		"### Closed\n"+
+		"Some **strong** and _em_ text, 2 * 3 * 4 and snake_case_name.\n"+
+		"- one\n- two\n- three\n"+
+		"---\n"+
+		"```\n__keep__ *this*\n```", s.Body)
+}
@@ -0,0 +1,107 @@
+package conform
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics counts how often each tag is applied, and how often it changes the value it's applied to, to find tags
+// that never change anything and the sanitizers that run most. It's safe for concurrent use, and is an expvar.Var,
+// so can be published with expvar.Publish.
+type Metrics struct {
+	mu     sync.RWMutex
+	counts map[string]*tagCounts
+	// OnApply, when set, is called each time a tag is applied, with its name, for counting in another metrics
+	// system such as Prometheus. Set it before conforming anything.
+	OnApply func(tag string, changed bool)
+}
+
+type tagCounts struct {
+	applied, changed atomic.Uint64
+}
+
+// TagCounts is how often a tag was applied, and how often that changed the value
+type TagCounts struct {
+	Applied uint64
+	Changed uint64
+}
+
+// NewMetrics returns Metrics with nothing counted
+func NewMetrics() *Metrics {
+	return &Metrics{counts: make(map[string]*tagCounts)}
+}
+
+// observe counts an application of the tag name
+func (m *Metrics) observe(name string, changed bool) {
+	m.mu.RLock()
+	c, ok := m.counts[name]
+	m.mu.RUnlock()
+	if !ok {
+		m.mu.Lock()
+		if c, ok = m.counts[name]; !ok {
+			c = &tagCounts{}
+			m.counts[name] = c
+		}
+		m.mu.Unlock()
+	}
+	c.applied.Add(1)
+	if changed {
+		c.changed.Add(1)
+	}
+	if m.OnApply != nil {
+		m.OnApply(name, changed)
+	}
+}
+
+// Counts returns the counts for each tag applied so far, keyed by the tag's name without its parameter, such as
+// "decimal" for "decimal=2". Each tag "a|b" tries is counted under its own name. Tags never applied are left out.
+func (m *Metrics) Counts() map[string]TagCounts {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := make(map[string]TagCounts, len(m.counts))
+	for name, c := range m.counts {
+		counts[name] = TagCounts{Applied: c.applied.Load(), Changed: c.changed.Load()}
+	}
+	return counts
+}
+
+// String returns the counts as a JSON object, such as {"trim": {"applied": 3, "changed": 1}}, for expvar
+func (m *Metrics) String() string {
+	counts := m.Counts()
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		// sanitizers can be named anything, so are quoted as JSON rather than as Go strings, which escape differently
+		key, _ := json.Marshal(name)
+		b.Write(key)
+		b.WriteString(`: {"applied": `)
+		b.WriteString(strconv.FormatUint(counts[name].Applied, 10))
+		b.WriteString(`, "changed": `)
+		b.WriteString(strconv.FormatUint(counts[name].Changed, 10))
+		b.WriteString("}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// metrics is the Metrics Strings counts tags in, set by WithMetrics
+var metrics *Metrics
+
+// WithMetrics makes Strings count the tags it applies in m. nil, the default, turns counting off, so there's no
+// overhead unless it's wanted. Call it before conforming anything, as when setting Strict.
+func WithMetrics(m *Metrics) {
+	metrics = m
+}
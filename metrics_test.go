@@ -0,0 +1,55 @@
+package conform
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestMetrics() {
+	assert := assert.New(t.T())
+
+	m := NewMetrics()
+	var mu sync.Mutex
+	applied := map[string]int{}
+	m.OnApply = func(tag string, changed bool) {
+		mu.Lock()
+		applied[tag]++
+		mu.Unlock()
+	}
+	WithMetrics(m)
+	defer WithMetrics(nil)
+
+	type user struct {
+		Email string   `conform:"trim,lower"`
+		Tags  []string `conform:"trim,wrap=72"`
+	}
+	assert.NoError(Strings(&user{Email: " a@b.c", Tags: []string{"x ", "y"}}))
+	assert.Equal(map[string]TagCounts{
+		"trim":  {Applied: 3, Changed: 2},
+		"lower": {Applied: 1, Changed: 0},
+		"wrap":  {Applied: 2, Changed: 0},
+	}, m.Counts())
+	assert.Equal(map[string]int{"trim": 3, "lower": 1, "wrap": 2}, applied)
+
+	var published map[string]map[string]uint64
+	assert.NoError(json.Unmarshal([]byte(m.String()), &published), "String should be JSON, for expvar")
+	assert.Equal(map[string]uint64{"applied": 3, "changed": 2}, published["trim"])
+	assert.Equal("{}", NewMetrics().String())
+
+	// strconv.Quote would write DEL as \x7f, which isn't JSON
+	r := NewRegistry()
+	r.AddSanitizer("del\x7f", func(s string) string { return s })
+	odd := NewMetrics()
+	assert.NoError(StringsWithOptions(&struct {
+		Name string `conform:"del\x7f"`
+	}{"a"}, Options{Registry: r, Metrics: odd}))
+	assert.NoError(json.Unmarshal([]byte(odd.String()), &published), "Any sanitizer's name should make valid JSON")
+	assert.Contains(published, "del\x7f")
+
+	other := NewMetrics()
+	assert.NoError(StringsWithOptions(&user{Email: "A"}, Options{Metrics: other}))
+	assert.Equal(TagCounts{Applied: 1, Changed: 1}, other.Counts()["lower"])
+	assert.Equal(uint64(3), m.Counts()["trim"].Applied, "Options should count in their own Metrics")
+}
@@ -0,0 +1,62 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MigrateOptions configures Migrate
+type MigrateOptions struct {
+	// Options configures how each record is conformed
+	Options Options
+	// Progress, when set, is called after each record with the counts so far, and the changes made to it
+	Progress func(MigrateProgress)
+	// DryRun conforms and diffs records without applying the changed ones, to see what a migration would change
+	DryRun bool
+}
+
+// MigrateProgress counts the records Migrate has read, changed and applied
+type MigrateProgress struct {
+	Read    int
+	Changed int
+	Applied int
+	// Changes are the strings changed in the record just read
+	Changes []FieldChange
+}
+
+// Migrate backfills conforming over records that already exist, such as the rows of a database. It reads records
+// from iter, a pointer to a struct at a time, until it returns false. Each is conformed and compared with how it
+// was, and only those that changed are passed to apply, to write back. A nil record is an error. The first error
+// from conforming a record or applying it stops the migration, and is returned with the counts so far, so it can
+// be resumed.
+func Migrate(iter func() (interface{}, bool), apply func(interface{}) error, opts MigrateOptions) (MigrateProgress, error) {
+	var progress MigrateProgress
+	for {
+		record, ok := iter()
+		if !ok {
+			return progress, nil
+		}
+		progress.Read++
+		if v := reflect.ValueOf(record); !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+			return progress, fmt.Errorf("record %d: nil", progress.Read)
+		}
+
+		before := deepCopy(reflect.ValueOf(record), map[ptrKey]reflect.Value{}).Interface()
+		if err := StringsWithOptions(record, opts.Options); err != nil {
+			return progress, fmt.Errorf("record %d: %w", progress.Read, err)
+		}
+		progress.Changes = Diff(before, record)
+		if len(progress.Changes) > 0 {
+			progress.Changed++
+			if !opts.DryRun {
+				if err := apply(record); err != nil {
+					return progress, fmt.Errorf("record %d: %w", progress.Read, err)
+				}
+				progress.Applied++
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package conform
+
+import (
+	"errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrateOrder struct {
+	Ref   string `conform:"trim"`
+	Items []*migrateItem
+}
+
+type migrateItem struct {
+	SKU   string `conform:"trim"`
+	Order *migrateOrder
+}
+
+func (t *testSuite) TestMigrate() {
+	assert := assert.New(t.T())
+
+	type row struct {
+		ID   int
+		Name string `conform:"trim"`
+	}
+	rows := func() func() (interface{}, bool) {
+		all := []*row{{1, " ann "}, {2, "bob"}, {3, "cat "}}
+		return func() (interface{}, bool) {
+			if len(all) == 0 {
+				return nil, false
+			}
+			r := all[0]
+			all = all[1:]
+			return r, true
+		}
+	}
+
+	var applied []row
+	var reads []int
+	progress, err := Migrate(rows(), func(r interface{}) error {
+		applied = append(applied, *r.(*row))
+		return nil
+	}, MigrateOptions{Progress: func(p MigrateProgress) { reads = append(reads, p.Read) }})
+	assert.NoError(err)
+	assert.Equal([]row{{1, "ann"}, {3, "cat"}}, applied, "Only changed records should be applied")
+	assert.Equal(MigrateProgress{Read: 3, Changed: 2, Applied: 2, Changes: []FieldChange{{Path: "Name", Before: "cat ", After: "cat"}}}, progress)
+	assert.Equal([]int{1, 2, 3}, reads)
+
+	progress, err = Migrate(rows(), func(interface{}) error {
+		panic("nothing should be applied in a dry run")
+	}, MigrateOptions{DryRun: true})
+	assert.NoError(err)
+	assert.Equal(2, progress.Changed)
+	assert.Equal(0, progress.Applied)
+
+	failed := errors.New("connection lost")
+	progress, err = Migrate(rows(), func(interface{}) error { return failed }, MigrateOptions{})
+	assert.ErrorIs(err, failed)
+	assert.EqualError(err, "record 1: connection lost")
+	assert.Equal(1, progress.Read)
+	assert.Equal(0, progress.Applied)
+
+	_, err = Migrate(func() (interface{}, bool) { return row{}, true }, nil, MigrateOptions{})
+	assert.ErrorIs(err, ErrNotPointer)
+
+	_, err = Migrate(func() (interface{}, bool) { return nil, true }, nil, MigrateOptions{})
+	assert.EqualError(err, "record 1: nil")
+	_, err = Migrate(func() (interface{}, bool) { return (*row)(nil), true }, nil, MigrateOptions{})
+	assert.EqualError(err, "record 1: nil")
+
+	o := &migrateOrder{Ref: " a "}
+	o.Items = []*migrateItem{{SKU: " b ", Order: o}}
+	progress, err = Migrate(func() (interface{}, bool) {
+		r := o
+		o = nil
+		return r, r != nil
+	}, func(interface{}) error { return nil }, MigrateOptions{})
+	assert.NoError(err, "Records pointing back at themselves should migrate")
+	assert.Equal(1, progress.Applied)
+	assert.Len(progress.Changes, 2)
+}
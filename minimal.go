@@ -0,0 +1,14 @@
+//go:build conform_minimal
+
+package conform
+
+// localeDirectives is empty in minimal builds, which leave out golang.org/x/text, so Locale has no effect
+var localeDirectives = map[string]func(locale string) transform{}
+
+// checkLocale accepts any locale in minimal builds, as they have no effect
+func checkLocale(locale string) error {
+	return nil
+}
+
+// elementDirectives is empty in minimal builds, as hashtags is left out
+var elementDirectives = map[string]directive{}
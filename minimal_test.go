@@ -0,0 +1,35 @@
+//go:build conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestMinimal() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Name  string `conform:"trim,lower,ucfirst"`
+		Bio   string `conform:"secure,maxwords=2"`
+		Email string `conform:"email"`
+		Slug  string `conform:"trim,slug"`
+	}
+	s.Name = "  JANE "
+	s.Bio = "one\u202e two three"
+	s.Email = "Jane@EXAMPLE.com"
+	s.Slug = " Left As Is "
+	err := Strings(&s)
+	assert.ErrorIs(err, ErrNotInMinimal, "Tags left out of minimal builds shouldn't be skipped quietly")
+	assert.EqualError(err, `Slug: slug: "slug" is not in conform_minimal builds`)
+
+	assert.Equal("Jane", s.Name)
+	assert.Equal("one two", s.Bio)
+	assert.Equal("Jane@example.com", s.Email)
+	assert.Equal(" Left As Is ", s.Slug)
+
+	_, err = Compile("!html")
+	assert.EqualError(err, `!html: "!html" is not in conform_minimal builds`)
+	_, err = Compile("nope")
+	assert.EqualError(err, `nope: unknown tag "nope"`)
+}
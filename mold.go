@@ -0,0 +1,48 @@
+package conform
+
+import "strings"
+
+// MoldTags is a TagParser for tags written for go-playground/mold, so structs can move to conform without
+// being retagged:
+//
+//	conform.StringsWithOptions(&v, conform.Options{TagName: "mold", TagParser: conform.MoldTags})
+//
+// "-" skips a field, and "0x2C" in a parameter stands for a comma, as in mold. conform already applies a
+// slice's or map's tags to each element, so "dive" is kept as it is, while map keys aren't conformed, so the
+// tags between "keys" and "endkeys" are dropped. So is "omitempty", as empty strings pass through most tags
+// unchanged. mold's names for tags conform has under others, such as "lcase", are renamed.
+func MoldTags(tag string) string {
+	if tag == "-" {
+		return ""
+	}
+	parts := strings.Split(tag, ",")
+	chain := parts[:0]
+	keys := false
+	for _, part := range parts {
+		switch {
+		case part == "keys":
+			keys = true
+		case part == "endkeys":
+			keys = false
+		case keys, part == "omitempty", part == "":
+		default:
+			if name, ok := moldNames[part]; ok {
+				part = name
+			}
+			chain = append(chain, strings.ReplaceAll(part, "0x2C", ","))
+		}
+	}
+	return strings.Join(chain, ",")
+}
+
+// moldNames are go-playground/mold's names for tags conform has under others, which MoldTags renames. mold's
+// strip_alpha, which only removes ASCII letters, and strip_num_unicode, which removes digits such as "٣", have no
+// tag that does the same, so are left as they are, as unknown tags Compile reports, rather than renamed to tags
+// that behave differently.
+var moldNames = map[string]string{
+	"lcase":               "lower",
+	"ucase":               "upper",
+	"strip_alpha_unicode": "!alpha",
+	"strip_num":           "!num",
+	"strip_punctuation":   "!punct",
+}
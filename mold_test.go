@@ -0,0 +1,82 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestMoldTags() {
+	assert := assert.New(t.T())
+
+	for tag, want := range map[string]string{
+		"trim,lower":                   "trim,lower",
+		"-":                            "",
+		"omitempty,trim":               "trim",
+		"dive,trim":                    "dive,trim",
+		"dive,keys,lower,endkeys,trim": "dive,trim",
+		"trimchars=0x2C.":              "trimchars=,.",
+		"trim,,upper":                  "trim,upper",
+	} {
+		assert.Equal(want, MoldTags(tag), tag)
+	}
+
+	type Inner struct {
+		City string `mold:"trim,upper"`
+	}
+	var s struct {
+		Name    string            `mold:"trim,lower"`
+		Skipped string            `mold:"-"`
+		Tags    []string          `mold:"dive,trim"`
+		Labels  map[string]string `mold:"dive,keys,lower,endkeys,trim"`
+		Inner   Inner
+		Ignored string `conform:"trim"`
+	}
+	s.Name = " ANN "
+	s.Skipped = " x "
+	s.Tags = []string{" a "}
+	s.Labels = map[string]string{"K": " v "}
+	s.Inner.City = " paris "
+	s.Ignored = " y "
+
+	assert.NoError(StringsWithOptions(&s, Options{TagName: "mold", TagParser: MoldTags}))
+	assert.Equal("ann", s.Name)
+	assert.Equal(" x ", s.Skipped)
+	assert.Equal([]string{"a"}, s.Tags)
+	assert.Equal(map[string]string{"K": "v"}, s.Labels)
+	assert.Equal("PARIS", s.Inner.City)
+	assert.Equal(" y ", s.Ignored)
+}
+
+func (t *testSuite) TestMoldAliases() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Lower   string `mold:"trim,lcase"`
+		Upper   string `mold:"ucase"`
+		Letters string `mold:"strip_num"`
+		Digits  string `mold:"strip_alpha_unicode"`
+		Words   string `mold:"strip_punctuation"`
+	}
+	s.Lower = " ANN "
+	s.Upper = "bob"
+	s.Letters = "abc123"
+	s.Digits = "abcé123"
+	s.Words = "well, hi!"
+	assert.NoError(StringsWithOptions(&s, Options{TagName: "mold", TagParser: MoldTags}))
+	assert.Equal("ann", s.Lower)
+	assert.Equal("BOB", s.Upper)
+	assert.Equal("abc", s.Letters)
+	assert.Equal("123", s.Digits)
+	assert.Equal("well hi", s.Words)
+	assert.Equal("strip_num_unicode,strip_alpha", MoldTags("strip_num_unicode,strip_alpha"),
+		"Tags conform has nothing quite like should be left to be reported")
+
+	r := NewRegistry()
+	r.AddSanitizer("strip_num", func(s string) string { return s + "!" })
+	own := struct {
+		Name string `conform:"strip_num"`
+	}{"abc123"}
+	assert.NoError(StringsWithOptions(&own, Options{Registry: r}))
+	assert.Equal("abc123!", own.Name, "mold's names shouldn't shadow sanitizers outside MoldTags")
+}
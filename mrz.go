@@ -0,0 +1,57 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// mrzLetters transliterates uppercase letters that don't simply lose their accents, following ICAO Doc 9303
+// part 3, including its table for Cyrillic
+var mrzLetters = strings.NewReplacer(
+	"Ä", "AE", "Å", "AA", "Æ", "AE", "Ĳ", "IJ", "Ø", "OE", "Œ", "OE", "Ö", "OE", "ẞ", "SS", "ß", "SS", "Þ", "TH",
+	"Ü", "UE", "Ð", "D", "Đ", "D", "Ħ", "H", "Ł", "L", "Ŀ", "L", "Ŋ", "N", "Ŧ", "T", "ı", "I",
+	"А", "A", "Б", "B", "В", "V", "Г", "G", "Ґ", "G", "Д", "D", "Е", "E", "Ё", "E", "Є", "IE", "Ж", "ZH", "З", "Z",
+	"И", "I", "І", "I", "Ї", "I", "Й", "I", "К", "K", "Л", "L", "М", "M", "Н", "N", "О", "O", "П", "P", "Р", "R",
+	"С", "S", "Т", "T", "У", "U", "Ф", "F", "Х", "KH", "Ц", "TS", "Ч", "CH", "Ш", "SH", "Щ", "SHCH", "Ъ", "IE",
+	"Ы", "Y", "Ь", "", "Э", "E", "Ю", "IU", "Я", "IA",
+)
+
+func mrzDirective(param string) (transform, error) {
+	if param == "" {
+		return func(s string) (string, error) { return mrz(s, 0), nil }, nil
+	}
+	return withInt(1, mrz)(param)
+}
+
+// mrz transliterates a name to the characters machine readable travel documents use, A to Z and the filler "<".
+// Spaces and hyphens between names become "<", and a comma, separating the surname from the given names, "<<".
+// Other characters are dropped. With max above 0, the result is cut to at most max characters.
+func mrz(s string, max int) string {
+	var parts []string
+	for _, part := range strings.Split(mrzLetters.Replace(strings.ToUpper(s)), ",") {
+		var names []string
+		for _, name := range strings.FieldsFunc(part, func(r rune) bool { return unicode.IsSpace(r) || r == '-' }) {
+			var b strings.Builder
+			for _, r := range norm.NFD.String(name) {
+				if r >= 'A' && r <= 'Z' {
+					b.WriteRune(r)
+				}
+			}
+			if b.Len() > 0 {
+				names = append(names, b.String())
+			}
+		}
+		if len(names) > 0 {
+			parts = append(parts, strings.Join(names, "<"))
+		}
+	}
+	out := strings.Join(parts, "<<")
+	if max > 0 && len(out) > max {
+		out = strings.TrimRight(out[:max], "<")
+	}
+	return out
+}
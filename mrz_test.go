@@ -0,0 +1,35 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestMRZ() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"Eriksson, Anna Maria":   "ERIKSSON<<ANNA<MARIA",
+		"Müller-Lüdenscheidt":    "MUELLER<LUEDENSCHEIDT",
+		"O'Brien, Seán":          "OBRIEN<<SEAN",
+		"Ærøskøbing":             "AEROESKOEBING",
+		"Groß":                   "GROSS",
+		"Łukasz Żółć":            "LUKASZ<ZOLC",
+		"Щербаков, Юрий":         "SHCHERBAKOV<<IURII",
+		"  de la   Cruz ,  josé": "DE<LA<CRUZ<<JOSE",
+		"":                       "",
+	} {
+		assert.Equal(want, mrz(in, 0), in)
+	}
+
+	assert.Equal("ERIKSSON<<ANNA", mrz("Eriksson, Anna Maria", 15), "Trailing fillers should be dropped when cut")
+	assert.Equal("ERIKSSON<<AN", mrz("Eriksson, Anna Maria", 12))
+
+	var s struct {
+		Name string `conform:"mrz=39"`
+	}
+	s.Name = "Van der Berg, Jan"
+	assert.NoError(Strings(&s))
+	assert.Equal("VAN<DER<BERG<<JAN", s.Name)
+}
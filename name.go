@@ -0,0 +1,126 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// NameRules customises how the "name" tag capitalises the individual words of a name
+type NameRules struct {
+	// Particles stay lowercase when followed by another word, e.g. "van" and "der" in "van der Berg"
+	Particles []string
+	// Prefixes capitalise the letter that follows them, e.g. "Mc" turns "mcdonald" into "McDonald"
+	Prefixes []string
+	// Exceptions replace whole words verbatim, keyed by their lowercase form, e.g. "macdonald": "MacDonald"
+	Exceptions map[string]string
+	// Suffixes are written as given when they end a name of more than one word, ignoring case and full stops,
+	// e.g. "Jr." turns "john smith jr" into "John Smith Jr." and "III" stops "Iii"
+	Suffixes []string
+}
+
+// nameRules holds the rules added with RegisterNameRules
+var nameRules = struct {
+	sync.RWMutex
+	particles  map[string]bool
+	prefixes   []string
+	exceptions map[string]string
+	// suffixes are keyed by suffixKey
+	suffixes map[string]string
+}{particles: map[string]bool{}, exceptions: map[string]string{}, suffixes: map[string]string{}}
+
+func init() {
+	RegisterNameRules(NameRules{
+		Particles: []string{"van", "von", "der", "den", "de", "del", "della", "du", "dos", "das", "da", "ter", "ten"},
+		Prefixes:  []string{"Mc"},
+		Suffixes:  []string{"Jr.", "Sr.", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X", "Esq."},
+	})
+}
+
+// RegisterNameRules adds particles, prefixes and exceptions to those used by the "name" tag
+func RegisterNameRules(rules NameRules) {
+	nameRules.Lock()
+	defer nameRules.Unlock()
+	for _, p := range rules.Particles {
+		nameRules.particles[strings.ToLower(p)] = true
+	}
+	nameRules.prefixes = append(nameRules.prefixes, rules.Prefixes...)
+	for k, v := range rules.Exceptions {
+		nameRules.exceptions[strings.ToLower(k)] = v
+	}
+	for _, suffix := range rules.Suffixes {
+		nameRules.suffixes[suffixKey(suffix)] = suffix
+	}
+}
+
+func suffixKey(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, ".", ""))
+}
+
+// applyNameRules adjusts the casing of an already title cased name
+func applyNameRules(name string) string {
+	nameRules.RLock()
+	defer nameRules.RUnlock()
+	words := strings.Split(name, " ")
+	for i, word := range words {
+		parts := strings.Split(word, "-")
+		for j, part := range parts {
+			parts[j] = namePart(part, i < len(words)-1 && len(parts) == 1)
+		}
+		words[i] = strings.Join(parts, "-")
+	}
+	for i := len(words) - 1; i > 0; i-- {
+		suffix, ok := nameRules.suffixes[suffixKey(words[i])]
+		if !ok {
+			break
+		}
+		words[i] = suffix
+	}
+	return strings.Join(words, " ")
+}
+
+// namePart capitalises a word of a name. The caller holds nameRules' read lock.
+func namePart(part string, canBeParticle bool) string {
+	lower := strings.ToLower(part)
+	if e, ok := nameRules.exceptions[lower]; ok {
+		return e
+	}
+	if canBeParticle && nameRules.particles[lower] {
+		return lower
+	}
+	for _, prefix := range nameRules.prefixes {
+		if len(lower) > len(prefix) && strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			return prefix + ucFirst(lower[len(prefix):])
+		}
+	}
+	return part
+}
+
+// replacement replaces each match of a pattern with a literal string
+type replacement struct {
+	re   *regexp.Regexp
+	with string
+}
+
+// nameReplacements tidy up a name before it's capitalised, in order
+var nameReplacements = []replacement{
+	{regexp.MustCompile("[^\\pL-\\s']"), ""}, // cut off everything except [ alpha, hyphen, whitespace, apostrophe]
+	{regexp.MustCompile("\\s{2,}"), " "},     // trim more than two whitespaces to one
+	{regexp.MustCompile("-{2,}"), "-"},       // trim more than two hyphens to one
+	{regexp.MustCompile("'{2,}"), "'"},       // trim more than two apostrophes to one
+	{regexp.MustCompile("( )*-( )*"), "-"},   // trim enclosing whitespaces around hyphen
+}
+
+func onlyOne(s string, m []replacement) string {
+	for _, r := range m {
+		s = r.re.ReplaceAllLiteralString(s, r.with)
+	}
+	return s
+}
+
+func formatName(s string) string {
+	first := onlyOne(strings.ToLower(s), nameReplacements)
+	return applyNameRules(strings.Title(patterns["name"].FindString(first)))
+}
@@ -0,0 +1,84 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestNameRules() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Mc       string `conform:"name"`
+		Particle string `conform:"name"`
+		Quote    string `conform:"name"`
+		Hyphen   string `conform:"name"`
+		Single   string `conform:"name"`
+	}
+
+	s.Mc = "  mcdonald "
+	s.Particle = "VAN DER BERG"
+	s.Quote = "o'neill"
+	s.Hyphen = "anne smith-mccoy"
+	s.Single = "van"
+	Strings(&s)
+
+	assert.Equal("McDonald", s.Mc, "Mc prefix should capitalise the following letter")
+	assert.Equal("van der Berg", s.Particle, "Particles should stay lowercase")
+	assert.Equal("O'Neill", s.Quote, "Apostrophes should capitalise the following letter")
+	assert.Equal("Anne Smith-McCoy", s.Hyphen, "Prefixes should apply to each part of a hyphenated name")
+	assert.Equal("Van", s.Single, "A lone particle should be title cased")
+}
+
+func (t *testSuite) TestRegisterNameRules() {
+	assert := assert.New(t.T())
+
+	RegisterNameRules(NameRules{
+		Particles:  []string{"bin"},
+		Exceptions: map[string]string{"macdonald": "MacDonald"},
+	})
+
+	var s struct {
+		Particle  string `conform:"name"`
+		Exception string `conform:"name"`
+	}
+
+	s.Particle = "ali bin hassan"
+	s.Exception = "old macdonald"
+	Strings(&s)
+
+	assert.Equal("Ali bin Hassan", s.Particle, "Registered particles should stay lowercase")
+	assert.Equal("Old MacDonald", s.Exception, "Registered exceptions should be used verbatim")
+
+	// registering while names are being conformed is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterNameRules(NameRules{Particles: []string{"al"}})
+	}()
+	formatName("omar al rashid")
+	wg.Wait()
+	assert.Equal("Omar al Rashid", formatName("omar al rashid"))
+}
+
+func (t *testSuite) TestNameSuffixes() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"henry viii":                "Henry VIII",
+		"JOHN SMITH, JR.":           "John Smith Jr.",
+		"martin luther king sr":     "Martin Luther King Sr.",
+		"thurston howell iii, esq.": "Thurston Howell III Esq.",
+		"iv":                        "Iv",
+		"vi smith":                  "Vi Smith",
+	} {
+		assert.Equal(want, formatName(in), in)
+	}
+
+	RegisterNameRules(NameRules{Suffixes: []string{"PhD"}})
+	assert.Equal("Jane Doe PhD", formatName("jane doe, ph.d."))
+}
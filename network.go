@@ -0,0 +1,41 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+var bareMAC = regexp.MustCompile(`^[0-9a-fA-F]{12}$`)
+
+// mac normalises a MAC address to lowercase, colon separated form.
+// Values that don't parse are returned as they are, with an error.
+func mac(s string) (string, error) {
+	trimmed := strings.TrimSpace(s)
+	if bareMAC.MatchString(trimmed) {
+		var parts []string
+		for i := 0; i < len(trimmed); i += 2 {
+			parts = append(parts, trimmed[i:i+2])
+		}
+		trimmed = strings.Join(parts, ":")
+	}
+	hw, err := net.ParseMAC(trimmed)
+	if err != nil {
+		return s, fmt.Errorf("%q is not a MAC address", s)
+	}
+	return hw.String(), nil
+}
+
+// ip normalises an IP address to its canonical form, compressing IPv6 addresses.
+// Values that don't parse are returned as they are, with an error.
+func ip(s string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return s, fmt.Errorf("%q is not an IP address", s)
+	}
+	return addr.String(), nil
+}
@@ -0,0 +1,55 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestMAC() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		"00:1A:2B:3C:4D:5E": "00:1a:2b:3c:4d:5e",
+		"00-1a-2b-3c-4d-5e": "00:1a:2b:3c:4d:5e",
+		"001A.2B3C.4D5E":    "00:1a:2b:3c:4d:5e",
+		" 001A2B3C4D5E ":    "00:1a:2b:3c:4d:5e",
+		"not a mac":         "not a mac",
+	} {
+		var s struct {
+			Addr string `conform:"mac"`
+		}
+		s.Addr = in
+		Strings(&s)
+		assert.Equal(expected, s.Addr, "mac of %q", in)
+	}
+}
+
+func (t *testSuite) TestIP() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		" 192.168.0.1 ": "192.168.0.1",
+		"2001:0DB8:0000:0000:0000:0000:0000:0001": "2001:db8::1",
+		"::FFFF:10.0.0.1":                         "::ffff:10.0.0.1",
+		"fe80::1%eth0":                            "fe80::1%eth0",
+		"192.168.0.256":                           "192.168.0.256",
+		"example.com":                             "example.com",
+	} {
+		var s struct {
+			Addr string `conform:"ip"`
+		}
+		s.Addr = in
+		Strings(&s)
+		assert.Equal(expected, s.Addr, "ip of %q", in)
+	}
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	var s struct {
+		Addr string `conform:"ip"`
+	}
+	s.Addr = "example.com"
+	assert.EqualError(Strings(&s), `Addr: ip: "example.com" is not an IP address`)
+}
@@ -0,0 +1,79 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// decimalInput is a number decimal accepts. big.Rat also parses fractions, hex and underscores, which aren't
+// numbers a user would type in, and exponents so large the number takes megabytes to write out, so the exponent
+// is limited to two digits.
+var decimalInput = regexp.MustCompile(`^[+-]?[0-9]*\.?[0-9]+([eE][+-]?[0-9]{1,2})?$`)
+
+// decimal reformats a number with a fixed number of decimal places, using a dot separator.
+// Rounding is exact and halves round away from zero. Values that don't parse are returned as they are, with an error.
+func decimal(s string, places int) (string, error) {
+	trimmed := strings.TrimSpace(s)
+	if !decimalInput.MatchString(trimmed) {
+		return s, fmt.Errorf("%q is not a decimal number", s)
+	}
+	r, ok := new(big.Rat).SetString(trimmed)
+	if !ok {
+		return s, fmt.Errorf("%q is not a decimal number", s)
+	}
+	return r.FloatString(places), nil
+}
+
+// thousandsStrip removes thousands separators: commas, underscores, apostrophes and spaces, including non-breaking ones
+func thousandsStrip(s string) string {
+	return stripRunes(s, ",_' \u00a0\u202f")
+}
+
+func stripRunes(s, cutset string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(cutset, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+var decimalNumber = regexp.MustCompile(`^[+-]?[0-9]*([.,][0-9]+)?$`)
+
+// decimalSep rewrites a number to use sep as its decimal separator, removing any thousands separators.
+// When both "." and "," appear the last one is taken as the decimal separator, and a separator appearing
+// more than once is taken as a thousands separator.
+func decimalSep(s, sep string) (string, error) {
+	trimmed := stripRunes(strings.TrimSpace(s), "_' \u00a0\u202f")
+	dec := -1
+	dots, commas := strings.Count(trimmed, "."), strings.Count(trimmed, ",")
+	switch {
+	case dots > 0 && commas > 0:
+		dec = strings.LastIndexAny(trimmed, ".,")
+	case dots == 1:
+		dec = strings.Index(trimmed, ".")
+	case commas == 1:
+		dec = strings.Index(trimmed, ",")
+	}
+
+	var b strings.Builder
+	for i, r := range trimmed {
+		switch {
+		case i == dec:
+			b.WriteString(sep)
+		case r == '.' || r == ',':
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	out := b.String()
+	if !decimalNumber.MatchString(out) || out == "" {
+		return s, fmt.Errorf("%q is not a decimal number", s)
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestDecimal() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Price    string `conform:"decimal=2"`
+		Round    string `conform:"decimal=2"`
+		Negative string `conform:"decimal=1"`
+		Whole    string `conform:"decimal=0"`
+		Money    string `conform:"leading_plus_strip,thousands_strip,decimal=2"`
+		Invalid  string `conform:"decimal=2"`
+	}
+
+	s.Price = " 12.5 "
+	s.Round = "2.675"
+	s.Negative = "-0.25"
+	s.Whole = "99.5"
+	s.Money = "+1,234,567.891"
+	s.Invalid = "abc"
+	assert.NoError(Strings(&s))
+
+	assert.Equal("12.50", s.Price)
+	assert.Equal("2.68", s.Round, "Halves should round away from zero")
+	assert.Equal("-0.3", s.Negative)
+	assert.Equal("100", s.Whole)
+	assert.Equal("1234567.89", s.Money)
+	assert.Equal("abc", s.Invalid, "Values that don't parse should be left alone")
+}
+
+func (t *testSuite) TestDecimalStrict() {
+	assert := assert.New(t.T())
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	var s struct {
+		Price string `conform:"trim,decimal=2"`
+	}
+
+	s.Price = " 1/3 "
+	err := Strings(&s)
+	assert.EqualError(err, `Price: decimal=2: "1/3" is not a decimal number`)
+
+	s.Price = " 1.5 "
+	assert.NoError(Strings(&s))
+	assert.Equal("1.50", s.Price)
+
+	for _, in := range []string{"0x1F", "1_000", "1e999999", "1e-999999", "0b101", "1.", "+", "1e"} {
+		out, err := decimal(in, 2)
+		assert.EqualError(err, fmt.Sprintf("%q is not a decimal number", in))
+		assert.Equal(in, out)
+	}
+	for in, want := range map[string]string{".5": "0.50", "1.5e3": "1500.00", "-2E-2": "-0.02", "1e99": "1" + strings.Repeat("0", 99) + ".00"} {
+		out, err := decimal(in, 2)
+		assert.NoError(err, in)
+		assert.Equal(want, out)
+	}
+}
+
+func (t *testSuite) TestThousandsStrip() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Commas string `conform:"thousands_strip"`
+		Spaces string `conform:"thousands_strip"`
+		Swiss  string `conform:"thousands_strip"`
+	}
+
+	s.Commas = "1,000,000"
+	s.Spaces = "1 000 000"
+	s.Swiss = "1'000'000.50"
+	Strings(&s)
+
+	assert.Equal("1000000", s.Commas)
+	assert.Equal("1000000", s.Spaces)
+	assert.Equal("1000000.50", s.Swiss)
+}
+
+func (t *testSuite) TestLeadingPlusStrip() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Plus  string `conform:"leading_plus_strip"`
+		Minus string `conform:"leading_plus_strip"`
+	}
+
+	s.Plus = "+42"
+	s.Minus = "-42"
+	Strings(&s)
+
+	assert.Equal("42", s.Plus)
+	assert.Equal("-42", s.Minus)
+}
+
+func (t *testSuite) TestDecimalSep() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		European  string `conform:"decimal_sep=."`
+		Comma     string `conform:"decimal_sep=."`
+		Already   string `conform:"decimal_sep=."`
+		Thousands string `conform:"decimal_sep=."`
+		Reverse   string `conform:"decimal_sep=comma"`
+		Spaced    string `conform:"decimal_sep=comma"`
+		Literal   string `conform:"decimal_sep=,"`
+		Chained   string `conform:"decimal_sep=,,trim"`
+		Invalid   string `conform:"decimal_sep=."`
+	}
+
+	s.European = "1.234,56"
+	s.Comma = "-0,5"
+	s.Already = "1234.56"
+	s.Thousands = "1.234.567"
+	s.Reverse = "1,234.56"
+	s.Spaced = "1 234 567.8"
+	s.Literal = "1,234.56"
+	s.Chained = "0.5"
+	s.Invalid = "12abc"
+	Strings(&s)
+
+	assert.Equal("1234.56", s.European)
+	assert.Equal("-0.5", s.Comma)
+	assert.Equal("1234.56", s.Already)
+	assert.Equal("1234567", s.Thousands)
+	assert.Equal("1234,56", s.Reverse)
+	assert.Equal("1234567,8", s.Spaced)
+	assert.Equal("1234,56", s.Literal, "A comma separator can be given as it is")
+	assert.Equal("0,5", s.Chained)
+	assert.Equal("12abc", s.Invalid, "Values that don't parse should be left alone")
+
+	Strict = true
+	defer func() { Strict = false }()
+	assert.EqualError(Strings(&s), `Invalid: decimal_sep=.: "12abc" is not a decimal number`)
+}
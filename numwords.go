@@ -0,0 +1,261 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NumberWords writes numbers in words in a language, for the "digits_to_words" and "words_to_digits" tags
+type NumberWords interface {
+	// Spell returns n in words, e.g. "twenty-one"
+	Spell(n uint64) string
+	// Digits replaces the numbers written in words in s with digits, e.g. "twenty-one apples" with "21 apples"
+	Digits(s string) string
+}
+
+// numberWords are those registered, keyed by lowercase language
+var numberWords = struct {
+	sync.RWMutex
+	m map[string]NumberWords
+}{m: map[string]NumberWords{"en": englishNumbers{}}}
+
+// RegisterNumberWords registers w for the language lang, used as in "digits_to_words=lang". English, "en", is
+// built in and used when no language is given.
+func RegisterNumberWords(lang string, w NumberWords) {
+	numberWords.Lock()
+	numberWords.m[strings.ToLower(lang)] = w
+	numberWords.Unlock()
+}
+
+// numberWordsFor returns the NumberWords for lang. Like lookup tables, they're looked up when applied, so they
+// can be registered after the tag is first used.
+func numberWordsFor(lang string) (NumberWords, error) {
+	if lang == "" {
+		lang = "en"
+	}
+	numberWords.RLock()
+	w, ok := numberWords.m[strings.ToLower(lang)]
+	numberWords.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no number words registered for %q", lang)
+	}
+	return w, nil
+}
+
+var integers = regexp.MustCompile(`[0-9]+`)
+
+// digitsToWords spells out the whole numbers in s. Numbers that are part of something else, such as "2.5",
+// "3rd", "mp3" or "007", are left as they are.
+func digitsToWords(s, lang string) (string, error) {
+	w, err := numberWordsFor(lang)
+	if err != nil {
+		return s, err
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range integers.FindAllStringIndex(s, -1) {
+		before, _ := utf8.DecodeLastRuneInString(s[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(s[loc[1]:])
+		next := byte(0)
+		if loc[1]+1 < len(s) {
+			next = s[loc[1]+1]
+		}
+		n, err := strconv.ParseUint(s[loc[0]:loc[1]], 10, 64)
+		if err != nil || (s[loc[0]] == '0' && loc[1]-loc[0] > 1) || unicode.IsLetter(before) || unicode.IsLetter(after) ||
+			before == '.' || before == ',' || ((after == '.' || after == ',') && next >= '0' && next <= '9') {
+			continue
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(w.Spell(n))
+		last = loc[1]
+	}
+	if last == 0 {
+		return s, nil
+	}
+	b.WriteString(s[last:])
+	return b.String(), nil
+}
+
+// wordsToDigits replaces the numbers written in words in s with digits
+func wordsToDigits(s, lang string) (string, error) {
+	w, err := numberWordsFor(lang)
+	if err != nil {
+		return s, err
+	}
+	return w.Digits(s), nil
+}
+
+// englishNumbers writes numbers in American English, without "and" after hundreds, though Digits accepts it
+type englishNumbers struct{}
+
+var (
+	englishSmall = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	englishTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = []struct {
+		value uint64
+		name  string
+	}{
+		{1e18, "quintillion"}, {1e15, "quadrillion"}, {1e12, "trillion"}, {1e9, "billion"}, {1e6, "million"},
+		{1e3, "thousand"},
+	}
+	// englishValues maps the words of numbers to their values. Scales, such as thousand, are multipliers.
+	englishValues = map[string]uint64{"hundred": 100}
+)
+
+func init() {
+	for i, w := range englishSmall {
+		englishValues[w] = uint64(i)
+	}
+	for i, w := range englishTens {
+		if w != "" {
+			englishValues[w] = uint64(i * 10)
+		}
+	}
+	for _, s := range englishScales {
+		englishValues[s.name] = s.value
+	}
+}
+
+// Spell returns n in words, e.g. "one thousand two hundred thirty-four"
+func (e englishNumbers) Spell(n uint64) string {
+	switch {
+	case n < 20:
+		return englishSmall[n]
+	case n < 100:
+		if n%10 == 0 {
+			return englishTens[n/10]
+		}
+		return englishTens[n/10] + "-" + englishSmall[n%10]
+	case n < 1000:
+		words := englishSmall[n/100] + " hundred"
+		if n%100 != 0 {
+			words += " " + e.Spell(n%100)
+		}
+		return words
+	}
+	for _, s := range englishScales {
+		if n >= s.value {
+			words := e.Spell(n/s.value) + " " + s.name
+			if n%s.value != 0 {
+				words += " " + e.Spell(n%s.value)
+			}
+			return words
+		}
+	}
+	return ""
+}
+
+var englishWords = regexp.MustCompile(`[A-Za-z]+`)
+
+// Digits replaces the numbers written in words in s with digits. Words must make a number as they'd be said,
+// so "one two" is left as two numbers. Numbers too big for a uint64, such as "twenty quintillion", are left in
+// words.
+func (englishNumbers) Digits(s string) string {
+	locs := englishWords.FindAllStringIndex(s, -1)
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(locs); {
+		var n englishNumber
+		if !n.add(strings.ToLower(s[locs[i][0]:locs[i][1]])) {
+			i++
+			continue
+		}
+		end := i
+		for k := i + 1; k < len(locs); k++ {
+			// words in a number are separated by spaces or hyphens
+			if strings.Trim(s[locs[k-1][1]:locs[k][0]], " -") != "" {
+				break
+			}
+			w := strings.ToLower(s[locs[k][0]:locs[k][1]])
+			// "and" follows hundreds or a scale, as in "two hundred and five" or "a thousand and one"
+			if w == "and" && k == end+1 && n.current%100 == 0 && n.total+n.current > 0 {
+				continue
+			}
+			if !n.add(w) {
+				break
+			}
+			end = k
+		}
+		if n.overflow {
+			i = end + 1
+			continue
+		}
+		b.WriteString(s[last:locs[i][0]])
+		b.WriteString(strconv.FormatUint(n.total+n.current, 10))
+		last = locs[end][1]
+		i = end + 1
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// englishNumber accumulates the words of a number. total holds what's been multiplied by a scale, and current
+// what's said since.
+type englishNumber struct {
+	total, current uint64
+	// scale is the last scale said, as each must be smaller than the one before
+	scale   uint64
+	started bool
+	zero    bool
+	// overflow is set once the number is too big for a uint64
+	overflow bool
+}
+
+// add adds a word to the number, reporting whether it continues it
+func (n *englishNumber) add(w string) bool {
+	v, ok := englishValues[w]
+	if !ok || n.zero {
+		return false
+	}
+	below := n.current % 100
+	switch {
+	case w == "zero":
+		if n.started {
+			return false
+		}
+		n.zero = true
+	case v < 10:
+		// after nothing, hundreds or tens, as in "one", "one hundred one" and "twenty-one"
+		if n.started && below != 0 && (below < 20 || below%10 != 0) {
+			return false
+		}
+		n.current += v
+	case v < 100:
+		if n.started && below != 0 {
+			return false
+		}
+		n.current += v
+	case v == 100:
+		if n.current == 0 || n.current >= 100 {
+			return false
+		}
+		n.current *= 100
+	default:
+		if n.current == 0 || (n.scale != 0 && v >= n.scale) {
+			return false
+		}
+		hi, lo := bits.Mul64(n.current, v)
+		var carry uint64
+		n.total, carry = bits.Add64(n.total, lo, 0)
+		n.overflow = n.overflow || hi != 0 || carry != 0
+		n.current = 0
+		n.scale = v
+	}
+	if _, carry := bits.Add64(n.total, n.current, 0); carry != 0 {
+		n.overflow = true
+	}
+	n.started = true
+	return true
+}
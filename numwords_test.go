@@ -0,0 +1,95 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestNumberWords() {
+	assert := assert.New(t.T())
+
+	en := englishNumbers{}
+	for n, want := range map[uint64]string{
+		0:          "zero",
+		7:          "seven",
+		13:         "thirteen",
+		40:         "forty",
+		42:         "forty-two",
+		100:        "one hundred",
+		101:        "one hundred one",
+		1234:       "one thousand two hundred thirty-four",
+		1000000:    "one million",
+		2000017:    "two million seventeen",
+		1000000000: "one billion",
+	} {
+		assert.Equal(want, en.Spell(n), "%d", n)
+		assert.Equal(strconv.FormatUint(n, 10), en.Digits(want), want)
+	}
+
+	for in, want := range map[string]string{
+		"two apples":                           "2 apples",
+		"Twenty-One Pilots":                    "21 Pilots",
+		"two hundred and five":                 "205",
+		"a thousand and one nights":            "a thousand and 1 nights",
+		"one thousand and one nights":          "1001 nights",
+		"one two three":                        "1 2 3",
+		"ten one":                              "10 1",
+		"rock and roll":                        "rock and roll",
+		"one and only":                         "1 and only",
+		"seven, eight":                         "7, 8",
+		"zero one":                             "0 1",
+		"thousands of them":                    "thousands of them",
+		"one million two hundred thousand six": "1200006",
+		"eighteen quintillion":                 "18000000000000000000",
+		"twenty quintillion dollars":           "twenty quintillion dollars",
+		"nine hundred quintillion":             "nine hundred quintillion",
+		"":                                     "",
+	} {
+		assert.Equal(want, en.Digits(in), in)
+	}
+
+	for in, want := range map[string]string{
+		"2 apples":     "two apples",
+		"21 pilots":    "twenty-one pilots",
+		"2.5 kg":       "2.5 kg",
+		"1,000 things": "1,000 things",
+		"3rd place":    "3rd place",
+		"mp3 and 007":  "mp3 and 007",
+		"0 left":       "zero left",
+		"1 or 2.":      "one or two.",
+	} {
+		out, err := digitsToWords(in, "")
+		assert.NoError(err)
+		assert.Equal(want, out, in)
+	}
+
+	_, err := digitsToWords("2", "fr")
+	assert.EqualError(err, `no number words registered for "fr"`)
+
+	// registering while numbers are being spelled is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterNumberWords("en-GB", en)
+	}()
+	digitsToWords("2", "en-gb")
+	wg.Wait()
+	out, err := digitsToWords("2", "en-gb")
+	assert.NoError(err)
+	assert.Equal("two", out)
+
+	var s struct {
+		Quantity string `conform:"trim,words_to_digits"`
+		Spelled  string `conform:"digits_to_words=en"`
+	}
+	s.Quantity = " Twelve "
+	s.Spelled = "12"
+	assert.NoError(Strings(&s))
+	assert.Equal("12", s.Quantity)
+	assert.Equal("twelve", s.Spelled)
+}
@@ -0,0 +1,317 @@
+package conform
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Options configures a single call to StringsWithOptions, for libraries embedding conform that can't rely on
+// package-level settings shared with the rest of the program
+type Options struct {
+	// TagName is the struct tag to read, "conform" if empty
+	TagName string
+	// Strict works like the package-level Strict, for this call only
+	Strict bool
+	// MaxDepth limits how deeply nested structs are followed, 0 meaning no limit. Deeper structs return an error.
+	MaxDepth int
+	// Parallelism conforms the elements of slices of structs on up to this many goroutines. 0 or 1 conforms them
+	// one after the other, as are elements whose structs hold pointers to other structs, which may be shared.
+	Parallelism int
+	// Locale is a BCP 47 language tag, such as "tr", whose rules the lower, upper and title tags follow
+	Locale string
+	// Registry holds the custom sanitizers to use, instead of those added with AddSanitizer
+	Registry *Registry
+	// RecurseFirst conforms the fields of a struct holding other structs, in slices and maps or not, before its
+	// other fields, so func tags on them see their nested structs conformed. Otherwise fields are conformed in
+	// the order they're declared.
+	RecurseFirst bool
+	// Interner, when set, interns the values conformed, so equal values share memory
+	Interner *Interner
+	// TagParser, when set, translates the tags read into conform's syntax, such as MoldTags for tags written
+	// for go-playground/mold
+	TagParser TagParser
+	// StrictRoot returns an error wrapping ErrUnsupportedRoot when the value passed points to something other
+	// than a struct, or a slice or map of them, which is otherwise left alone. Wiring mistakes, such as passing
+	// a pointer to a pointer, are caught rather than leaving input unconformed.
+	StrictRoot bool
+	// Logger, when set, logs each field changed at debug level, as WithLogger does for Strings
+	Logger *slog.Logger
+	// Metrics, when set, counts the tags applied, as WithMetrics does for Strings
+	Metrics *Metrics
+	// RuleVersion conforms every struct with the rules registered for it with RegisterRuleVersion, rather than
+	// their fields' tags or the versions they're marked with, such as to re-conform records stored under it
+	RuleVersion string
+}
+
+// TagParser translates a struct tag written in another syntax into a comma separated chain of conform tags.
+// An empty chain leaves the field alone.
+type TagParser func(tag string) string
+
+// Registry is a set of custom sanitizers, for callers that need their own rather than those added with
+// AddSanitizer
+type Registry struct {
+	mu         sync.RWMutex
+	sanitizers map[string]sanitizer
+	// pipelines caches compiled tags, keyed by pipelineKey. When full, a random entry is dropped to make room, as
+	// chains passed to String or Rules may be built at runtime.
+	pipelinesMu sync.RWMutex
+	pipelines   map[pipelineKey]*Pipeline
+	// renames is the value tagRenames had when pipelines was last cleared
+	renames uint64
+}
+
+// maxPipelines bounds each Registry's pipelines cache
+const maxPipelines = 1024
+
+// pipelineKey is a struct, rather than the locale and tags joined into one string, so looking up a pipeline that's
+// been compiled doesn't allocate
+type pipelineKey struct {
+	locale, tags string
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{sanitizers: map[string]sanitizer{}, pipelines: map[pipelineKey]*Pipeline{}}
+}
+
+// AddSanitizer associates a sanitizer with a key, which can be used in a Struct tag
+func (r *Registry) AddSanitizer(key string, s sanitizer) {
+	r.mu.Lock()
+	r.sanitizers[key] = s
+	r.mu.Unlock()
+	// pipelines compiled before the sanitizer was added would skip it
+	r.clearPipelines()
+}
+
+// clearPipelines drops the cached pipelines, so they're compiled again with the tags as they now resolve
+func (r *Registry) clearPipelines() {
+	r.pipelinesMu.Lock()
+	r.pipelines = map[pipelineKey]*Pipeline{}
+	r.pipelinesMu.Unlock()
+}
+
+func (r *Registry) sanitizer(key string) (sanitizer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sanitizers[key]
+	return s, ok
+}
+
+// pipeline returns the cached pipeline for tags, compiling it on first use
+func (r *Registry) pipeline(tags, locale string) *Pipeline {
+	// pipelines compiled before a tag was deprecated would skip it
+	if renames := atomic.LoadUint64(&tagRenames); atomic.LoadUint64(&r.renames) != renames {
+		r.clearPipelines()
+		atomic.StoreUint64(&r.renames, renames)
+	}
+	key := pipelineKey{locale, tags}
+	r.pipelinesMu.RLock()
+	p, ok := r.pipelines[key]
+	r.pipelinesMu.RUnlock()
+	if ok {
+		return p
+	}
+
+	p, _ = r.compile(tags, locale)
+	r.pipelinesMu.Lock()
+	defer r.pipelinesMu.Unlock()
+	if len(r.pipelines) >= maxPipelines {
+		dropOne(r.pipelines)
+	}
+	r.pipelines[key] = p
+	return p
+}
+
+// StringsWithOptions conforms strings based on reflection tags, like Strings, configured by opts. When a Tracer is
+// set, its spans start new traces, so use StringsWithOptionsContext to trace the call as part of a request.
+func StringsWithOptions(iface interface{}, opts Options) error {
+	return stringsContext(context.Background(), iface, opts)
+}
+
+// StringsWithOptionsContext conforms strings like StringsWithOptions, tracing the call as part of ctx when a
+// Tracer is set
+func StringsWithOptionsContext(ctx context.Context, iface interface{}, opts Options) error {
+	return stringsContext(ctx, iface, opts)
+}
+
+// walker conforms a value according to a call's options
+type walker struct {
+	opts Options
+	// parent points to the struct holding the value being conformed, and path locates the value within the
+	// value passed to Strings, for func tags
+	parent interface{}
+	path   string
+	// paths is set when there are field funcs to give paths to. Otherwise building them would be wasted work.
+	paths bool
+	// stats counts the strings conformed when the call is traced
+	stats *walkStats
+	// version is the rule version the fields of the struct being conformed follow, with its rules, if any
+	version string
+	rules   Rules
+}
+
+// newWalker fills in the defaults for any options left unset
+func newWalker(opts Options) walker {
+	if opts.TagName == "" {
+		opts.TagName = "conform"
+	}
+	if opts.Registry == nil {
+		opts.Registry = defaultRegistry
+	}
+	if !loggingEnabled(opts.Logger) {
+		opts.Logger = nil
+	}
+	// logged changes are located by their paths
+	return walker{opts: opts, paths: hasFieldFuncs() || opts.Logger != nil}
+}
+
+// at returns a walker for the value at path, held by the struct parent points to
+func (w walker) at(parent interface{}, path string) walker {
+	w.parent, w.path = parent, path
+	return w
+}
+
+// fieldPath returns the path to the field name of the struct w locates
+func (w walker) fieldPath(name string) string {
+	if !w.paths {
+		return ""
+	}
+	return join(w.path, name)
+}
+
+// elementPath returns the path to the element of the slice or map w locates at key
+func (w walker) elementPath(key interface{}) string {
+	if !w.paths {
+		return ""
+	}
+	return fmt.Sprintf("%s[%v]", w.path, key)
+}
+
+// fieldOrders caches the order fields are conformed in, keyed by fieldOrderKey
+var fieldOrders sync.Map
+
+type fieldOrderKey struct {
+	t            reflect.Type
+	tagName      string
+	recurseFirst bool
+	version      string
+}
+
+// fieldOrder returns the indexes of t's fields in the order they're conformed
+func (w walker) fieldOrder(t reflect.Type) []int {
+	// funcs can't be compared, so orders found with a TagParser aren't cached
+	key := fieldOrderKey{t, w.opts.TagName, w.opts.RecurseFirst, w.version}
+	if w.opts.TagParser == nil {
+		if order, ok := fieldOrders.Load(key); ok {
+			return order.([]int)
+		}
+	}
+	order := make([]int, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if !w.opts.RecurseFirst || w.holdsStructs(t.Field(i)) {
+			order = append(order, i)
+		}
+	}
+	if w.opts.RecurseFirst {
+		for i := 0; i < t.NumField(); i++ {
+			if !w.holdsStructs(t.Field(i)) {
+				order = append(order, i)
+			}
+		}
+	}
+	if w.opts.TagParser == nil {
+		fieldOrders.Store(key, order)
+	}
+	return order
+}
+
+// tags returns the chain of tags on f, translated by the TagParser if there is one, or its rule when the walker
+// follows a rule version. Version markers have none.
+func (w walker) tags(f reflect.StructField) string {
+	if w.rules != nil {
+		return w.rules[f.Name]
+	}
+	tags := f.Tag.Get(w.opts.TagName)
+	if _, ok := markedVersion(f, w.opts.TagName); ok {
+		return ""
+	}
+	if w.opts.TagParser != nil && tags != "" {
+		return w.opts.TagParser(tags)
+	}
+	return tags
+}
+
+// holdsStructs reports whether conforming f means conforming structs nested in it. Tagged struct fields, such
+// as sql.NullString, are conformed as strings.
+func (w walker) holdsStructs(f reflect.StructField) bool {
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return w.tags(f) == ""
+	case reflect.Slice, reflect.Map:
+		el := t.Elem()
+		if el.Kind() == reflect.Ptr {
+			el = el.Elem()
+		}
+		return el.Kind() == reflect.Struct
+	}
+	return false
+}
+
+func (w walker) transformValue(tags string, val reflect.Value) (reflect.Value, error) {
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return val, nil
+	}
+
+	var oldStr string
+	if val.Kind() == reflect.Ptr {
+		oldStr = val.Elem().String()
+	} else {
+		oldStr = val.String()
+	}
+
+	newStr, err := w.transformString(oldStr, tags)
+	if err != nil {
+		return val, err
+	}
+	// unchanged strings are only replaced to intern them, which pointers to them never are
+	if newStr == oldStr && (w.opts.Interner == nil || val.Kind() == reflect.Ptr) {
+		return val, nil
+	}
+
+	if val.Kind() == reflect.Ptr {
+		// a copy, so newStr only moves to the heap when there's a pointer to it
+		ptr := newStr
+		return reflect.ValueOf(&ptr).Convert(val.Type()), nil
+	}
+	return reflect.ValueOf(newStr).Convert(val.Type()), nil
+}
+
+func (w walker) transformString(input, tags string) (string, error) {
+	ctx := FieldContext{Parent: w.parent, Path: w.path, metrics: w.opts.Metrics}
+	if w.opts.Logger != nil {
+		ctx.fired = new([]string)
+	}
+	p := w.opts.Registry.pipeline(tags, w.opts.Locale)
+	out, err := p.applyField(input, w.opts.Strict, &ctx)
+	if w.opts.Logger != nil && out != input {
+		w.logChange(input, out, *ctx.fired, p.nolog)
+	}
+	if w.opts.Interner != nil && tags != "" {
+		out = w.opts.Interner.Intern(out)
+	}
+	if w.stats != nil && tags != "" {
+		w.stats.fields.Add(1)
+		if out != input {
+			w.stats.changed.Add(1)
+		}
+	}
+	return out, err
+}
@@ -0,0 +1,137 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestStringsWithOptions() {
+	assert := assert.New(t.T())
+
+	type Child struct {
+		Name string `conform:"upper" mold:"trim"`
+	}
+	type Form struct {
+		Name     string `conform:"upper" mold:"trim,shout"`
+		City     string `conform:"title" mold:"upper"`
+		Price    string `conform:"decimal=2"`
+		Children []Child
+	}
+
+	registry := NewRegistry()
+	registry.AddSanitizer("shout", func(s string) string { return s + "!" })
+
+	f := Form{Name: " ann ", City: "istanbul", Children: []Child{{Name: " bob "}, {Name: " eve "}}}
+	assert.NoError(StringsWithOptions(&f, Options{TagName: "mold", Registry: registry, Locale: "tr", Parallelism: 2}))
+	assert.Equal("ann!", f.Name)
+	assert.Equal("İSTANBUL", f.City, "Turkish upper cases i with a dot")
+	assert.Equal("bob", f.Children[0].Name)
+	assert.Equal("eve", f.Children[1].Name)
+
+	f = Form{Name: " ann ", Price: "lots"}
+	err := StringsWithOptions(&f, Options{Strict: true})
+	var stepErr *StepError
+	assert.True(errors.As(err, &stepErr))
+	assert.Equal(" ANN ", f.Name)
+	assert.False(Strict, "Options shouldn't change the package-level settings")
+
+	_, ok := defaultRegistry.sanitizer("shout")
+	assert.False(ok, "Sanitizers in a registry shouldn't leak into the default one")
+}
+
+func (t *testSuite) TestMaxDepth() {
+	assert := assert.New(t.T())
+
+	type Node struct {
+		Name string `conform:"trim"`
+		Next *Node
+	}
+	n := &Node{Name: " a ", Next: &Node{Name: " b ", Next: &Node{Name: " c "}}}
+	assert.NoError(StringsWithOptions(n, Options{MaxDepth: 2}))
+	assert.Equal("c", n.Next.Next.Name)
+
+	err := StringsWithOptions(n, Options{MaxDepth: 1})
+	if assert.Error(err) {
+		assert.True(strings.HasPrefix(err.Error(), "Next: Next: structs nested more than MaxDepth (1) deep"), err.Error())
+	}
+
+	loop := &Node{Name: " loop "}
+	loop.Next = loop
+	assert.NoError(StringsWithOptions(loop, Options{MaxDepth: 100}), "Cycles should end before MaxDepth")
+	assert.Equal("loop", loop.Name)
+}
+
+type parallelParent struct {
+	Name     string `conform:"trim"`
+	Children []*parallelChild
+}
+
+type parallelChild struct {
+	Name   string `conform:"trim"`
+	Parent *parallelParent
+}
+
+func (t *testSuite) TestParallelism() {
+	assert := assert.New(t.T())
+
+	type Item struct {
+		Price string `conform:"decimal=2"`
+	}
+	items := struct{ Items []*Item }{}
+	for i := 0; i < 50; i++ {
+		items.Items = append(items.Items, &Item{Price: "1.5"})
+	}
+	items.Items[10].Price = "bad"
+	items.Items[30].Price = "worse"
+	items.Items = append(items.Items, nil)
+
+	err := StringsWithOptions(&items, Options{Parallelism: 8, Strict: true})
+	assert.EqualError(err, `Items: Price: decimal=2: "bad" is not a decimal number`, "The first element to fail should be reported")
+	assert.Equal("1.50", items.Items[49].Price)
+
+	parent := &parallelParent{Name: " parent "}
+	for i := 0; i < 8; i++ {
+		parent.Children = append(parent.Children, &parallelChild{Name: " child ", Parent: parent})
+	}
+	assert.NoError(StringsWithOptions(parent, Options{Parallelism: 4}), "Cycles through the elements should end")
+	assert.Equal("parent", parent.Name)
+	assert.Equal("child", parent.Children[7].Name)
+
+	r := NewRegistry()
+	r.AddSanitizer("exclaim", func(s string) string { return s + "!" })
+	type shout struct {
+		Text string `conform:"exclaim"`
+	}
+	shared := &shout{Text: "hi"}
+	shouts := []*shout{shared, shared, shared, {Text: "bye"}}
+	assert.NoError(StringsWithOptions(&shouts, Options{Parallelism: 4, Registry: r}))
+	assert.Equal("hi!", shared.Text, "Elements sharing a pointer should be conformed once")
+	assert.Equal("bye!", shouts[3].Text)
+}
+
+func (t *testSuite) TestUnchangedDoesNotAllocate() {
+	assert := assert.New(t.T())
+
+	w := newWalker(Options{})
+	p, _ := Compile("trim,lower")
+	var s struct {
+		Name  string `conform:"trim,lower"`
+		Code  string `conform:"upper"`
+		Label string `conform:"ltrim,rtrim"`
+	}
+	s.Name, s.Code, s.Label = "jane", "GB", "as is"
+
+	for name, f := range map[string]func(){
+		"transformString": func() { w.transformString("jane", "trim,lower") },
+		"Apply":           func() { p.Apply("jane") },
+		"Strings":         func() { Strings(&s) },
+	} {
+		f()
+		assert.Zero(testing.AllocsPerRun(100, f), name)
+	}
+}
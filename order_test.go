@@ -0,0 +1,73 @@
+package conform
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderChild struct {
+	Name string `conform:"func=test_order_log,upper"`
+}
+
+type orderParent struct {
+	Title    string `conform:"func=test_order_log,trim"`
+	Child    orderChild
+	Children []orderChild
+	Summary  string `conform:"func=test_order_summary"`
+}
+
+func (t *testSuite) TestOrder() {
+	assert := assert.New(t.T())
+
+	var log []string
+	RegisterFieldFunc("test_order_log", func(ctx FieldContext) error {
+		log = append(log, ctx.Path)
+		return nil
+	})
+	RegisterFieldFunc("test_order_summary", func(ctx FieldContext) error {
+		p := ctx.Parent.(*orderParent)
+		log = append(log, ctx.Path)
+		ctx.Set(fmt.Sprintf("%s: %s", p.Title, p.Child.Name))
+		return nil
+	})
+	RegisterTypeHook(func(c *orderChild) error {
+		log = append(log, "hook "+c.Name)
+		return nil
+	})
+
+	fresh := func() orderParent {
+		return orderParent{Title: " t ", Child: orderChild{Name: "a"}, Children: []orderChild{{Name: "b"}}}
+	}
+
+	p := fresh()
+	assert.NoError(Strings(&p))
+	assert.Equal([]string{"Title", "Child.Name", "hook A", "Children[0].Name", "hook B", "Summary"}, log,
+		"Fields should be conformed in the order they're declared, each struct's hooks after its fields")
+	assert.Equal("t: A", p.Summary, "Tags should run left to right, and later fields see earlier ones conformed")
+
+	log = nil
+	p = fresh()
+	p.Summary = ""
+	assert.NoError(StringsWithOptions(&p, Options{RecurseFirst: true}))
+	assert.Equal([]string{"Child.Name", "hook A", "Children[0].Name", "hook B", "Title", "Summary"}, log,
+		"Fields holding structs should be conformed first")
+
+	type reordered struct {
+		Summary string `conform:"func=test_order_summary"`
+		Title   string
+		Child   orderChild
+	}
+	log = nil
+	RegisterFieldFunc("test_order_summary", func(ctx FieldContext) error {
+		r := ctx.Parent.(*reordered)
+		ctx.Set(r.Child.Name)
+		return nil
+	})
+	r := reordered{Child: orderChild{Name: "c"}}
+	assert.NoError(Strings(&r))
+	assert.Equal("c", r.Summary, "Without RecurseFirst, a func before a struct sees it unconformed")
+	r = reordered{Child: orderChild{Name: "c"}}
+	assert.NoError(StringsWithOptions(&r, Options{RecurseFirst: true}))
+	assert.Equal("C", r.Summary)
+}
@@ -0,0 +1,171 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+)
+
+// phonetic codes each word of s with code, after dropping diacritics and anything other than letters, and
+// separates the codes with spaces
+func phonetic(s string, code func(string) string) string {
+	var codes []string
+	for _, word := range strings.Fields(searchKey(s, false)) {
+		word = strings.Map(func(r rune) rune {
+			if r < 'a' || r > 'z' {
+				return -1
+			}
+			return r - 'a' + 'A'
+		}, word)
+		if word != "" {
+			codes = append(codes, code(word))
+		}
+	}
+	return strings.Join(codes, " ")
+}
+
+// soundexDigits are the American Soundex digits of the letters A to Z. Vowels, 0, separate letters with the same
+// digit, while H and W, "-", don't.
+const soundexDigits = "0123012-02245501262301-202"
+
+// soundex returns the American Soundex code of an uppercase word, its first letter and three digits
+func soundex(w string) string {
+	code := []byte{w[0]}
+	last := soundexDigits[w[0]-'A']
+	for i := 1; i < len(w) && len(code) < 4; i++ {
+		switch d := soundexDigits[w[i]-'A']; {
+		case d == '-':
+		case d == '0':
+			last = d
+		case d != last:
+			code = append(code, d)
+			last = d
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("AEIOU", c) != -1
+}
+
+// metaphone returns the Metaphone code of an uppercase word, as described by Lawrence Philips in 1990
+func metaphone(w string) string {
+	// doubled letters sound as one, other than C, as in "accept"
+	b := make([]byte, 0, len(w))
+	for i := 0; i < len(w); i++ {
+		if i == 0 || w[i] != w[i-1] || w[i] == 'C' {
+			b = append(b, w[i])
+		}
+	}
+	w = string(b)
+	for _, silent := range []string{"KN", "GN", "PN", "AE", "WR"} {
+		if strings.HasPrefix(w, silent) {
+			w = w[1:]
+			break
+		}
+	}
+	switch {
+	case strings.HasPrefix(w, "X"):
+		w = "S" + w[1:]
+	case strings.HasPrefix(w, "WH"):
+		w = "W" + w[2:]
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	var code strings.Builder
+	for i := 0; i < len(w); i++ {
+		prev, c, next := at(i-1), w[i], at(i+1)
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code.WriteByte(c)
+			}
+		case 'B':
+			if prev != 'M' || i != len(w)-1 {
+				code.WriteByte('B')
+			}
+		case 'C':
+			switch {
+			case prev == 'S' && strings.IndexByte("EIY", next) != -1:
+			case next == 'I' && at(i+2) == 'A':
+				code.WriteByte('X')
+			case strings.IndexByte("EIY", next) != -1:
+				code.WriteByte('S')
+			case next == 'H' && prev != 'S':
+				code.WriteByte('X')
+			default:
+				code.WriteByte('K')
+			}
+		case 'D':
+			if next == 'G' && strings.IndexByte("EIY", at(i+2)) != -1 {
+				code.WriteByte('J')
+				i++
+			} else {
+				code.WriteByte('T')
+			}
+		case 'G':
+			switch {
+			case next == 'H' && i+2 < len(w) && !isVowel(at(i+2)):
+			case next == 'N' && (i+2 == len(w) || w[i+2:] == "ED"):
+			case strings.IndexByte("EIY", next) != -1:
+				code.WriteByte('J')
+			default:
+				code.WriteByte('K')
+			}
+		case 'H':
+			if isVowel(next) && strings.IndexByte("CGPST", prev) == -1 {
+				code.WriteByte('H')
+			}
+		case 'K':
+			if prev != 'C' {
+				code.WriteByte('K')
+			}
+		case 'P':
+			if next == 'H' {
+				code.WriteByte('F')
+			} else {
+				code.WriteByte('P')
+			}
+		case 'Q':
+			code.WriteByte('K')
+		case 'S':
+			if next == 'H' || (next == 'I' && (at(i+2) == 'O' || at(i+2) == 'A')) {
+				code.WriteByte('X')
+			} else {
+				code.WriteByte('S')
+			}
+		case 'T':
+			switch {
+			case next == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code.WriteByte('X')
+			case next == 'H':
+				code.WriteByte('0')
+			case next == 'C' && at(i+2) == 'H':
+			default:
+				code.WriteByte('T')
+			}
+		case 'V':
+			code.WriteByte('F')
+		case 'W', 'Y':
+			if isVowel(next) {
+				code.WriteByte(c)
+			}
+		case 'X':
+			code.WriteString("KS")
+		case 'Z':
+			code.WriteByte('S')
+		default:
+			code.WriteByte(c)
+		}
+	}
+	return code.String()
+}
@@ -0,0 +1,38 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestPhonetic() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"Robert": "R163", "Rupert": "R163", "Rubin": "R150", "Ashcraft": "A261", "Ashcroft": "A261",
+		"Tymczak": "T522", "Pfister": "P236", "Honeyman": "H555", "Lee": "L000",
+		"  Jöhn O'Brien-Smith ": "J500 O165 S530", "123": "",
+	} {
+		assert.Equal(want, phonetic(in, soundex), in)
+	}
+
+	for in, want := range map[string]string{
+		"Smith": "SM0", "Knight": "NT", "Philip": "FLP", "Xavier": "SFR", "Wright": "RT",
+		"Catherine": "K0RN", "Thumb": "0M", "Science": "SNS", "Judge": "JJ", "Accept": "AKSPT",
+		"Whistle": "WSTL", "Nation": "NXN", "Sign": "SN", "Yolanda": "YLNT", "Aero": "ER",
+		"Jon Smyth": "JN SM0",
+	} {
+		assert.Equal(want, phonetic(in, metaphone), in)
+	}
+
+	var s struct {
+		Name string `conform:"soundex"`
+		Last string `conform:"metaphone"`
+	}
+	s.Name = "Robert"
+	s.Last = "Schmidt"
+	assert.NoError(Strings(&s))
+	assert.Equal("R163", s.Name)
+	assert.Equal("SKMTT", s.Last)
+}
@@ -0,0 +1,49 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:generate go run pinyin_gen.go
+
+// pinyin romanizes Chinese characters as Hanyu Pinyin without tone marks, for "translit=zh-pinyin". Each
+// character is given its most common reading, so one read differently in a word, such as 行 in 银行 (yinhang), is
+// romanized as it's usually read. Syllables are separated from each other, and the letters and digits around
+// them, by spaces. Characters outside the CJK Unified Ideographs block are left as they are.
+type pinyin struct{}
+
+var (
+	pinyinOnce sync.Once
+	// pinyinReadings are split from pinyinTable on first use, as most programs never need them
+	pinyinReadings []string
+)
+
+// Transliterate romanizes s
+func (pinyin) Transliterate(s string) string {
+	pinyinOnce.Do(func() { pinyinReadings = strings.Fields(pinyinTable) })
+	var b strings.Builder
+	b.Grow(len(s))
+	// spaced is set when the last rune written was a letter or digit, which a syllable is separated from
+	spaced, afterSyllable := false, false
+	for _, r := range s {
+		if i := int(r) - pinyinFirst; i >= 0 && i < len(pinyinReadings) && pinyinReadings[i] != "-" {
+			if spaced {
+				b.WriteByte(' ')
+			}
+			b.WriteString(pinyinReadings[i])
+			spaced, afterSyllable = true, true
+			continue
+		}
+		spaced = unicode.IsLetter(r) || unicode.IsDigit(r)
+		if spaced && afterSyllable {
+			b.WriteByte(' ')
+		}
+		afterSyllable = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
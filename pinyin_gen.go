@@ -0,0 +1,64 @@
+//go:build ignore
+
+// This program generates pinyin_table.go, the readings "translit=zh-pinyin" gives Chinese characters, from ICU's
+// Han-Latin transliterator. It needs ICU's uconv command, and is run with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+)
+
+const first, last = 0x4E00, 0x9FFF
+
+func main() {
+	var in bytes.Buffer
+	for r := rune(first); r <= last; r++ {
+		in.WriteRune(r)
+		in.WriteByte('\n')
+	}
+	cmd := exec.Command("uconv", "-x", "Han-Latin; Latin-ASCII")
+	cmd.Stdin = &in
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		log.Fatal(err)
+	}
+	readings := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(readings) != last-first+1 {
+		log.Fatalf("uconv gave %d readings for %d characters", len(readings), last-first+1)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by pinyin_gen.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "//go:build !conform_minimal\n\npackage conform\n\n")
+	fmt.Fprintf(&b, "// pinyinFirst is the first character pinyinTable has a reading for\n")
+	fmt.Fprintf(&b, "const pinyinFirst = 0x%X\n\n", first)
+	fmt.Fprintf(&b, "// pinyinTable holds the reading of each character from pinyinFirst on, separated by whitespace, or \"-\" for\n")
+	fmt.Fprintf(&b, "// those without one\n")
+	fmt.Fprintf(&b, "const pinyinTable = `")
+	width := 0
+	for i, reading := range readings {
+		if r, _ := utf8.DecodeRuneInString(reading); r == rune(first+i) || reading == "" {
+			reading = "-"
+		}
+		if width > 0 && width+1+len(reading) > 110 {
+			b.WriteByte('\n')
+			width = 0
+		} else if width > 0 {
+			b.WriteByte(' ')
+			width++
+		}
+		b.WriteString(reading)
+		width += len(reading)
+	}
+	b.WriteString("`\n")
+	if err := os.WriteFile("pinyin_table.go", b.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,343 @@
+package conform
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// transform applies a single tag to a string. When it can't, it returns the value to use instead along with an error.
+type transform func(string) (string, error)
+
+// directive builds the transform for a tag from its parameter, the part after "=", or returns an error if the
+// parameter is invalid.
+type directive func(param string) (transform, error)
+
+// StepError reports which tag in a chain failed, and why
+type StepError struct {
+	// Tag is the tag as written, e.g. "decimal=2"
+	Tag string
+	// Index is the position of the tag in the chain, starting at 0
+	Index int
+	Err   error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Tag, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// step is a tag in a compiled chain
+type step struct {
+	tag string
+	// alts are the tags "a|b" tries in turn, or the tag on its own
+	alts []alt
+	// index is the tag's position in the chain, counting those that aren't steps, such as nolog
+	index int
+}
+
+// alt is one of the tags a step tries
+type alt struct {
+	name  string
+	param string
+	fn    transform
+}
+
+// Pipeline is a compiled chain of tags, which can be applied to strings without reflection
+type Pipeline struct {
+	steps []step
+	// nolog keeps the values of fields conformed with the pipeline out of logs
+	nolog bool
+	// validAfter is the number of steps up to and including the last built-in tag, after which the value is made
+	// valid UTF-8. Validating once, rather than after each built-in, leaves invalid bytes for decode= to fix.
+	validAfter int
+	// err is the *StepError for a tag left out of minimal builds, returned whenever the pipeline is applied
+	err error
+	// invalid is the *StepError for a tag with an invalid parameter, returned when the pipeline is applied strictly
+	invalid error
+}
+
+// Compile parses a comma separated chain of tags, such as "trim,lower,wrap=72", into a Pipeline.
+// Unlike struct tags, which skip them, unknown tags and invalid parameters are reported as a *StepError.
+func Compile(tags string) (Pipeline, error) {
+	p, err := compile(tags)
+	if err != nil {
+		return Pipeline{}, err
+	}
+	return *p, nil
+}
+
+// Apply runs the pipeline on s. As with Strings, tags that can't be applied leave the value as it is,
+// unless Strict is set, in which case the chain stops and a *StepError is returned.
+func (p Pipeline) Apply(s string) (string, error) {
+	return p.apply(s, Strict)
+}
+
+// String returns the chain of tags the pipeline was compiled from
+func (p Pipeline) String() string {
+	tags := make([]string, len(p.steps))
+	for i, s := range p.steps {
+		tags[i] = s.tag
+	}
+	return strings.Join(tags, ",")
+}
+
+// compiledPipeline returns the cached pipeline for tags, compiling it on first use. Unknown tags and tags with
+// invalid parameters are skipped, as they always have been, though the latter return their error when Strict.
+func compiledPipeline(tags string) *Pipeline {
+	return defaultRegistry.pipeline(tags, "")
+}
+
+// compile parses a comma separated chain of tags using the sanitizers added with AddSanitizer
+func compile(tags string) (*Pipeline, error) {
+	return defaultRegistry.compile(tags, "")
+}
+
+// compile parses a comma separated chain of tags. Steps that fail to compile are left out of the pipeline,
+// and the first failure is returned.
+func (r *Registry) compile(tags, locale string) (*Pipeline, error) {
+	p := &Pipeline{}
+	var first error
+	if tags == "" {
+		return p, nil
+	}
+	split := r.expandAliases(SplitTags(tags))
+	// "locale=de" sets the locale for the whole chain, wherever it appears
+	for _, tag := range split {
+		if name, param := splitTag(tag); name == "locale" {
+			locale = param
+		}
+	}
+	// dive marks the value as one element of a slice or map, for tags that treat elements differently
+	dive := false
+	for i, tag := range split {
+		name, param := splitTag(tag)
+		if name == "dive" {
+			dive = true
+			continue
+		}
+		if name == "locale" {
+			if err := checkLocale(param); err != nil && first == nil {
+				first = &StepError{Tag: tag, Index: i, Err: err}
+				p.invalid = first
+			}
+			continue
+		}
+		if name == "nolog" {
+			p.nolog = true
+			continue
+		}
+
+		// "a|b" tries a, falling back to b when a fails. Unknown tags are left out of the tags tried, so the step is
+		// only left out when none of them are known.
+		s := step{tag: tag, index: i}
+		tried := alternatives(tag)
+		for _, t := range tried {
+			a := alt{}
+			var err error
+			if a.name, a.param = splitTag(t); a.name == "func" && len(tried) > 1 {
+				err = errors.New("func can't be used with |")
+			} else {
+				a.fn, err = r.compileTag(t, dive, locale)
+			}
+			if err == nil {
+				s.alts = append(s.alts, a)
+				continue
+			}
+			stepErr := &StepError{Tag: tag, Index: i, Err: err}
+			if first == nil {
+				first = stepErr
+			}
+			switch {
+			case errors.Is(err, ErrNotInMinimal):
+				if p.err == nil {
+					p.err = stepErr
+				}
+			case !errors.Is(err, ErrUnknownDirective):
+				if p.invalid == nil {
+					p.invalid = stepErr
+				}
+			}
+		}
+		if len(s.alts) == 0 {
+			continue
+		}
+
+		p.steps = append(p.steps, s)
+		for _, t := range tried {
+			if isBuiltin(t, dive) {
+				p.validAfter = len(p.steps)
+			}
+		}
+	}
+	return p, first
+}
+
+// compileTag builds the transform for a single tag
+func (r *Registry) compileTag(tag string, dive bool, locale string) (transform, error) {
+	name, param := splitTag(tag)
+	if d, ok := elementDirectives[name]; ok && dive {
+		return r.builtin(d, name, param, locale)
+	}
+	if d, ok := directives[name]; ok {
+		return r.builtin(d, name, param, locale)
+	}
+	if s, ok := r.sanitizer(tag); ok {
+		return func(in string) (string, error) { return s(in), nil }, nil
+	}
+	for _, full := range fullTags {
+		if name == full {
+			return nil, fmt.Errorf("%q is %w", name, ErrNotInMinimal)
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownDirective, name)
+}
+
+// isBuiltin reports whether tag is one of the package's tags, rather than a custom sanitizer
+func isBuiltin(tag string, dive bool) bool {
+	name, _ := splitTag(tag)
+	_, element := elementDirectives[name]
+	_, ok := directives[name]
+	return ok || element && dive
+}
+
+// builtin builds the transform for a built in tag. A locale swaps the case tags for ones that follow its rules.
+func (r *Registry) builtin(d directive, name, param, locale string) (transform, error) {
+	fn, err := d(param)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := localeDirectives[name]; ok && locale != "" && param == "" {
+		fn = c(locale)
+	}
+	return fn, nil
+}
+
+// splitTag splits a parameterised tag, which takes the form "name=param"
+func splitTag(tag string) (name, param string) {
+	if j := strings.Index(tag, "="); j != -1 {
+		return tag[:j], tag[j+1:]
+	}
+	return tag, ""
+}
+
+// alternatives splits a tag on "|". As with commas, a part that doesn't start a tag belongs to the parameter
+// before it, so "trimchars=|" is a single tag.
+func alternatives(tag string) []string {
+	var alts []string
+	for _, part := range strings.Split(tag, "|") {
+		if n := len(alts); n > 0 && !startsTag(part) {
+			alts[n-1] += "|" + part
+			continue
+		}
+		alts = append(alts, part)
+	}
+	return alts
+}
+
+// apply tries each of the step's tags in turn on a struct field described by ctx, or a string on its own when ctx
+// is nil, returning the first that succeeds. When they all fail, the last failure is returned. Each tag tried is
+// counted under its own name.
+func (s step) apply(input string, ctx *FieldContext) (string, error) {
+	var out string
+	var err error
+	for _, a := range s.alts {
+		if a.name == "func" && ctx != nil {
+			out, err = applyFunc(a.param, input, *ctx)
+		} else {
+			out, err = a.fn(input)
+		}
+		if ctx != nil && ctx.metrics != nil {
+			ctx.metrics.observe(a.name, out != input)
+		}
+		if err == nil {
+			return out, nil
+		}
+	}
+	return out, err
+}
+
+// SplitTags splits a chain of tags on commas. As a parameter can contain commas, such as "trimchars=.,;", a
+// part that doesn't start with a letter or "!" continues the parameter of the tag before it.
+func SplitTags(tags string) []string {
+	var split []string
+	for _, part := range strings.Split(tags, ",") {
+		if n := len(split); n > 0 && strings.Contains(split[n-1], "=") && !startsTag(part) {
+			split[n-1] += "," + part
+			continue
+		}
+		split = append(split, part)
+	}
+	return split
+}
+
+func startsTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '!' || unicode.IsLetter(r)
+}
+
+// apply runs each step in turn. When strict, the first step to fail stops the chain and its error is returned.
+// Otherwise the chain carries on with the value the failing step gave back.
+func (p *Pipeline) apply(input string, strict bool) (string, error) {
+	return p.applyField(input, strict, nil)
+}
+
+// applyField runs each step in turn, like apply, on a struct field described by ctx. Without a field, func
+// tags fail. Built-in tags never give back invalid UTF-8, though the steps between them may see it. When strict,
+// a tag with an invalid parameter fails before any step runs; otherwise it's skipped like an unknown tag.
+func (p *Pipeline) applyField(input string, strict bool, ctx *FieldContext) (string, error) {
+	if strict && p.invalid != nil {
+		return input, p.invalid
+	}
+	for i, s := range p.steps {
+		out, err := s.apply(input, ctx)
+		if err != nil && strict {
+			if p.validAfter > 0 {
+				input = validUTF8(input, false)
+			}
+			return input, &StepError{Tag: s.tag, Index: s.index, Err: err}
+		}
+		if i+1 == p.validAfter {
+			out = validUTF8(out, false)
+		}
+		if ctx != nil && ctx.fired != nil && out != input {
+			*ctx.fired = append(*ctx.fired, s.tag)
+		}
+		input = out
+	}
+	return input, p.err
+}
+
+// plain adapts a func that can't fail and takes no parameter
+func plain(f func(string) string) directive {
+	return func(string) (transform, error) {
+		return func(s string) (string, error) { return f(s), nil }, nil
+	}
+}
+
+// fallible adapts a func that can fail and takes no parameter
+func fallible(f func(string) (string, error)) directive {
+	return func(string) (transform, error) {
+		return f, nil
+	}
+}
+
+// withInt adapts a func that takes an integer parameter of at least min
+func withInt(min int, f func(string, int) string) directive {
+	return func(param string) (transform, error) {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < min {
+			return nil, fmt.Errorf("%q is not a whole number of at least %d", param, min)
+		}
+		return func(s string) (string, error) { return f(s, n), nil }, nil
+	}
+}
@@ -0,0 +1,239 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestCompile() {
+	assert := assert.New(t.T())
+
+	p, err := compile("trim,nope,wrap=abc,upper")
+	assert.Len(p.steps, 2, "Unknown tags and bad parameters should be skipped")
+	assert.Equal("trim", p.steps[0].alts[0].name)
+	assert.Equal("upper", p.steps[1].alts[0].name)
+
+	var stepErr *StepError
+	if assert.True(errors.As(err, &stepErr)) {
+		assert.Equal("nope", stepErr.Tag)
+		assert.Equal(1, stepErr.Index)
+	}
+
+	p, err = compile("trim,decimal=2")
+	assert.NoError(err)
+	assert.Equal("decimal", p.steps[1].alts[0].name)
+	assert.Equal("2", p.steps[1].alts[0].param)
+
+	out, err := p.apply(" 1.5 ", true)
+	assert.NoError(err)
+	assert.Equal("1.50", out)
+}
+
+func (t *testSuite) TestStepError() {
+	assert := assert.New(t.T())
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	var s struct {
+		Price string `conform:"trim,thousands_strip,decimal=2"`
+	}
+	s.Price = " lots "
+
+	err := Strings(&s)
+	var stepErr *StepError
+	if assert.True(errors.As(err, &stepErr)) {
+		assert.Equal("decimal=2", stepErr.Tag)
+		assert.Equal(2, stepErr.Index)
+	}
+	assert.Equal(" lots ", s.Price, "The field should be left alone when its chain fails")
+
+	// tags that aren't steps, such as nolog, still count towards the index
+	_, err = compile("trim,nolog,decimal=x")
+	if assert.True(errors.As(err, &stepErr)) {
+		assert.Equal(2, stepErr.Index)
+	}
+	p, _ := compile("trim,nolog,decimal=2")
+	_, err = p.apply("lots", true)
+	if assert.True(errors.As(err, &stepErr)) {
+		assert.Equal(2, stepErr.Index, "Applying should count tags as compiling does")
+	}
+}
+
+func (t *testSuite) TestInvalidParameter() {
+	assert := assert.New(t.T())
+
+	var zero struct {
+		Handle string `conform:"handle=0"`
+	}
+	var word struct {
+		Handle string `conform:"handle=abc"`
+	}
+	var locale struct {
+		Handle string `conform:"trim,locale=notalocale!"`
+	}
+	Strict = true
+	for _, v := range []interface{}{&zero, &word, &locale} {
+		var stepErr *StepError
+		assert.True(errors.As(Strings(v), &stepErr), "Invalid parameters should return an error when strict: %T", v)
+	}
+	Strict = false
+
+	var mixed struct {
+		Bad  string `conform:"trim,wrap=abc"`
+		Name string `conform:"trim,upper"`
+	}
+	mixed.Bad, mixed.Name = " x ", "  bob "
+	assert.NoError(Strings(&mixed), "Invalid parameters should be skipped unless strict")
+	assert.Equal("x", mixed.Bad, "The valid tags in the chain should still be applied")
+	assert.Equal("BOB", mixed.Name, "Fields after one with an invalid parameter should still be conformed")
+
+	var s struct {
+		Handle string `conform:"nope,handle"`
+	}
+	s.Handle = " @Lee "
+	assert.NoError(Strings(&s), "Unknown tags should still be skipped")
+	assert.Equal("lee", s.Handle)
+}
+
+func (t *testSuite) TestPipelineCache() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Code string `conform:"trim,shout_late"`
+	}
+
+	s.Code = " hi "
+	Strings(&s)
+	assert.Equal("hi", s.Code)
+
+	AddSanitizer("shout_late", func(s string) string { return s + "!" })
+	s.Code = " hi "
+	Strings(&s)
+	assert.Equal("hi!", s.Code, "Sanitizers added after a chain was compiled should be used")
+
+	r := NewRegistry()
+	for i := 0; i < maxPipelines+10; i++ {
+		r.pipeline("wrap="+strconv.Itoa(i+1), "")
+	}
+	assert.Len(r.pipelines, maxPipelines, "The cache should hold no more than its maximum")
+}
+
+func (t *testSuite) TestPublicCompile() {
+	assert := assert.New(t.T())
+
+	p, err := Compile("trim,lower,wrap=5")
+	assert.NoError(err)
+	assert.Equal("trim,lower,wrap=5", p.String())
+
+	out, err := p.Apply("  HELLO WORLD ")
+	assert.NoError(err)
+	assert.Equal("hello\nworld", out)
+
+	_, err = Compile("trim,lowr")
+	assert.EqualError(err, `lowr: unknown tag "lowr"`)
+
+	_, err = Compile("trim,wrap=-1")
+	assert.EqualError(err, `wrap=-1: "-1" is not a whole number of at least 1`)
+
+	p, err = Compile("decimal=2")
+	assert.NoError(err)
+	out, err = p.Apply("abc")
+	assert.NoError(err, "Apply should only fail in Strict mode")
+	assert.Equal("abc", out)
+
+	Strict = true
+	defer func() { Strict = false }()
+	_, err = p.Apply("abc")
+	assert.EqualError(err, `decimal=2: "abc" is not a decimal number`)
+}
+
+func (t *testSuite) TestFallback() {
+	assert := assert.New(t.T())
+
+	p, err := Compile("trim,decimal=2|hexcolor")
+	assert.NoError(err)
+	assert.Equal("trim,decimal=2|hexcolor", p.String())
+	assert.Equal([]alt{{name: "decimal", param: "2"}, {name: "hexcolor"}}, altNames(p.steps[1].alts),
+		"Each tag tried should have its own name and parameter")
+
+	out, err := p.apply(" 1.5 ", true)
+	assert.NoError(err)
+	assert.Equal("1.50", out)
+
+	out, err = p.apply(" #FFF ", true)
+	assert.NoError(err, "The next tag should be tried when the first fails")
+	assert.Equal("#ffffff", out)
+
+	out, err = p.apply(" nope ", false)
+	assert.NoError(err)
+	assert.Equal("nope", out)
+
+	_, err = p.apply(" nope ", true)
+	assert.EqualError(err, `decimal=2|hexcolor: "nope" is not a color`, "The last failure should be reported")
+
+	_, err = Compile("decimal=2|nope")
+	assert.EqualError(err, `decimal=2|nope: unknown tag "nope"`)
+
+	p, err = Compile("trimchars=|")
+	assert.NoError(err, "A | that doesn't start a tag should be part of the parameter")
+	out, _ = p.Apply("|a|")
+	assert.Equal("a", out)
+
+	var s struct {
+		Amount string `conform:"decimal=2|num"`
+	}
+	s.Amount = "12 apples"
+	m := NewMetrics()
+	assert.NoError(StringsWithOptions(&s, Options{Metrics: m}))
+	assert.Equal("12", s.Amount)
+	assert.Equal(map[string]TagCounts{
+		"decimal": {Applied: 1, Changed: 0},
+		"num":     {Applied: 1, Changed: 1},
+	}, m.Counts(), "Each tag a|b tries should be counted under its own name")
+
+	var unknown struct {
+		First string `conform:"phone=US|num"`
+		Last  string `conform:"num|nosuch"`
+		None  string `conform:"trim,nope|nosuch"`
+	}
+	unknown.First, unknown.Last, unknown.None = "12 apples", "34 pears", " as is "
+	assert.NoError(Strings(&unknown))
+	assert.Equal("12", unknown.First, "Unknown tags should be left out of those tried")
+	assert.Equal("34", unknown.Last, "Known tags should be kept whatever follows them")
+	assert.Equal("as is", unknown.None, "Steps with no known tags should be left out")
+}
+
+// altNames drops the transforms from alts, so they can be compared
+func altNames(alts []alt) []alt {
+	out := make([]alt, len(alts))
+	for i, a := range alts {
+		out[i] = alt{name: a.name, param: a.param}
+	}
+	return out
+}
+
+func (t *testSuite) TestString() {
+	assert := assert.New(t.T())
+
+	s := "  Bob@EXAMPLE.com "
+	assert.NoError(String(&s, "trim,email"))
+	assert.Equal("Bob@example.com", s)
+
+	s = " x "
+	assert.NoError(String(&s, "trim,notatag"), "Unknown tags should be skipped, as on struct fields")
+	assert.Equal("x", s)
+
+	assert.NoError(String(nil, "trim"))
+
+	Strict = true
+	defer func() { Strict = false }()
+	s = "abc"
+	err := String(&s, "func=missing")
+	assert.Error(err)
+	assert.Equal("abc", s)
+}
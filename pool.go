@@ -0,0 +1,30 @@
+package conform
+
+import "sync"
+
+// runeBuffers holds the buffers tags that work on runes decode strings into, so conforming in bulk doesn't
+// allocate one for every value
+var runeBuffers = sync.Pool{New: func() interface{} {
+	b := make([]rune, 0, 64)
+	return &b
+}}
+
+// maxPooledRunes stops an unusually long value from keeping a large buffer alive
+const maxPooledRunes = 4096
+
+// getRunes decodes s into a buffer from the pool. Return it with putRunes once done with it.
+func getRunes(s string) *[]rune {
+	b := runeBuffers.Get().(*[]rune)
+	rs := (*b)[:0]
+	for _, r := range s {
+		rs = append(rs, r)
+	}
+	*b = rs
+	return b
+}
+
+func putRunes(b *[]rune) {
+	if cap(*b) <= maxPooledRunes {
+		runeBuffers.Put(b)
+	}
+}
@@ -0,0 +1,40 @@
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestRunePool() {
+	assert := assert.New(t.T())
+
+	b := getRunes("héllo")
+	assert.Equal([]rune("héllo"), *b)
+	putRunes(b)
+	b = getRunes("ok")
+	assert.Equal([]rune("ok"), *b, "A reused buffer should only hold the new string")
+	putRunes(b)
+}
+
+type scratchItem struct {
+	SKU string `conform:"upper,func=test_keep_parent"`
+}
+
+func (t *testSuite) TestScratchValues() {
+	assert := assert.New(t.T())
+
+	var parents []*scratchItem
+	RegisterFieldFunc("test_keep_parent", func(ctx FieldContext) error {
+		parents = append(parents, ctx.Parent.(*scratchItem))
+		return nil
+	})
+	var s struct {
+		Items map[string]scratchItem
+	}
+	s.Items = map[string]scratchItem{"a": {"ab"}, "b": {"cd"}}
+	assert.NoError(Strings(&s))
+	assert.Equal(map[string]scratchItem{"a": {"AB"}, "b": {"CD"}}, s.Items, "Each map value should be conformed in its own scratch value")
+	if assert.Len(parents, 2) {
+		assert.Equal("AB", parents[0].SKU, "Parents kept after the call shouldn't be reused")
+		assert.Equal("CD", parents[1].SKU)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !conform_minimal
+
+package conform
+
+import "strings"
+
+// postalFormats maps an ISO country code to a func that formats an uppercased postal code with spaces removed
+var postalFormats = map[string]func(string) string{
+	"US": func(s string) string {
+		s = strings.Replace(s, "-", "", -1)
+		if len(s) == 9 {
+			return s[:5] + "-" + s[5:]
+		}
+		return s
+	},
+	"CA": func(s string) string {
+		if len(s) == 6 {
+			return s[:3] + " " + s[3:]
+		}
+		return s
+	},
+	"GB": func(s string) string {
+		// the inward code is always the last three characters
+		if len(s) >= 5 && len(s) <= 7 {
+			return s[:len(s)-3] + " " + s[len(s)-3:]
+		}
+		return s
+	},
+}
+
+// postal strips spaces, uppercases and re-inserts the canonical spacing for a country's postal codes
+func postal(s, country string) string {
+	f, ok := postalFormats[strings.ToUpper(country)]
+	if !ok {
+		return s
+	}
+	return f(strings.ToUpper(strings.Join(strings.Fields(s), "")))
+}
@@ -0,0 +1,35 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestPostal() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		US      string `conform:"postal=US"`
+		USPlus4 string `conform:"postal=US"`
+		CA      string `conform:"postal=CA"`
+		GB      string `conform:"postal=GB"`
+		GBShort string `conform:"postal=gb"`
+		Unknown string `conform:"postal=XX"`
+	}
+
+	s.US = " 90210 "
+	s.USPlus4 = "12345 6789"
+	s.CA = "k1a0b1"
+	s.GB = "sw1a1aa"
+	s.GBShort = " m1  1ae"
+	s.Unknown = "abc 123"
+	Strings(&s)
+
+	assert.Equal("90210", s.US)
+	assert.Equal("12345-6789", s.USPlus4)
+	assert.Equal("K1A 0B1", s.CA)
+	assert.Equal("SW1A 1AA", s.GB)
+	assert.Equal("M1 1AE", s.GBShort)
+	assert.Equal("abc 123", s.Unknown, "Unknown countries should be left alone")
+}
@@ -0,0 +1,29 @@
+package conform
+
+import "fmt"
+
+// Rules maps field names to chains of tags, for records that aren't structs, such as JSON objects or CSV rows
+type Rules map[string]string
+
+// Validate compiles every rule, returning the first unknown tag or invalid parameter
+func (r Rules) Validate() error {
+	for field, tags := range r {
+		if _, err := compile(tags); err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// Apply conforms value using the rule for field. Fields without a rule are returned unchanged.
+func (r Rules) Apply(field, value string) (string, error) {
+	tags, ok := r[field]
+	if !ok {
+		return value, nil
+	}
+	out, err := compiledPipeline(tags).apply(value, Strict)
+	if err != nil {
+		return out, fmt.Errorf("%s: %w", field, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestRules() {
+	assert := assert.New(t.T())
+
+	rules := Rules{
+		"email": "trim,email",
+		"price": "thousands_strip,decimal=2",
+	}
+	assert.NoError(rules.Validate())
+
+	out, err := rules.Apply("email", " Bob@Example.COM ")
+	assert.NoError(err)
+	assert.Equal("Bob@example.com", out)
+
+	out, err = rules.Apply("name", " Bob ")
+	assert.NoError(err)
+	assert.Equal(" Bob ", out, "Fields without a rule should be left alone")
+
+	Strict = true
+	defer func() { Strict = false }()
+	_, err = rules.Apply("price", "lots")
+	var stepErr *StepError
+	assert.True(errors.As(err, &stepErr))
+	assert.Contains(err.Error(), "price: decimal=2")
+
+	err = Rules{"name": "trim,nmae"}.Validate()
+	assert.EqualError(err, `name: nmae: unknown tag "nmae"`)
+}
@@ -0,0 +1,217 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// unaccented are letters without a decomposition that drops their marks
+var unaccented = strings.NewReplacer("ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l", "đ", "d", "ð", "d",
+	"þ", "th", "ı", "i")
+
+// searchKey lowercases s and drops its diacritics and punctuation, leaving its words separated by single spaces,
+// for matching text however it was typed. With stem, English words are reduced to their stems, so "running"
+// matches "runs".
+func searchKey(s string, stem bool) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range norm.NFD.String(unaccented.Replace(strings.ToLower(s))) {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\'', r == '’':
+			// marks and apostrophes join the letters around them, so "don't" becomes "dont"
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte(' ')
+		}
+	}
+	words := strings.Fields(b.String())
+	if stem {
+		for i, w := range words {
+			words[i] = porterStem(w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// porterStem reduces an English word to its stem with the Porter algorithm, as in "An algorithm for suffix
+// stripping" (Porter, 1980). Words that aren't lowercase ASCII letters, or are shorter than three, are left as
+// they are.
+func porterStem(w string) string {
+	if len(w) <= 2 {
+		return w
+	}
+	for i := 0; i < len(w); i++ {
+		if w[i] < 'a' || w[i] > 'z' {
+			return w
+		}
+	}
+	w = porterStep1a(w)
+	w = porterStep1b(w)
+	if strings.HasSuffix(w, "y") && hasVowel(w[:len(w)-1]) {
+		w = w[:len(w)-1] + "i"
+	}
+	w = replaceSuffix(w, porterStep2)
+	w = replaceSuffix(w, porterStep3)
+	w = porterStep4(w)
+	if strings.HasSuffix(w, "e") {
+		stem := w[:len(w)-1]
+		if m := measure(stem); m > 1 || (m == 1 && !cvc(stem)) {
+			w = stem
+		}
+	}
+	if measure(w) > 1 && doubleConsonant(w) && strings.HasSuffix(w, "l") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+func porterStep1a(w string) string {
+	switch {
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "ies"):
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "ss"):
+		return w
+	case strings.HasSuffix(w, "s"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+func porterStep1b(w string) string {
+	if strings.HasSuffix(w, "eed") {
+		if measure(w[:len(w)-3]) > 0 {
+			return w[:len(w)-1]
+		}
+		return w
+	}
+	for _, suffix := range []string{"ed", "ing"} {
+		stem := strings.TrimSuffix(w, suffix)
+		if stem == w || !hasVowel(stem) {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "bl"), strings.HasSuffix(stem, "iz"):
+			return stem + "e"
+		case doubleConsonant(stem) && !strings.ContainsRune("lsz", rune(stem[len(stem)-1])):
+			return stem[:len(stem)-1]
+		case measure(stem) == 1 && cvc(stem):
+			return stem + "e"
+		}
+		return stem
+	}
+	return w
+}
+
+// porterSuffix replaces a suffix with another
+type porterSuffix struct {
+	suffix, replacement string
+}
+
+// porterStep2 and porterStep3 are ordered so the longest suffix that matches comes first
+var (
+	porterStep2 = []porterSuffix{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"},
+		{"abli", "able"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"},
+		{"ation", "ate"}, {"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+		{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	}
+	porterStep3 = []porterSuffix{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"}, {"ful", ""},
+		{"ness", ""},
+	}
+	porterStep4Suffixes = []string{
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent", "ion", "ou", "ism", "ate",
+		"iti", "ous", "ive", "ize",
+	}
+)
+
+// replaceSuffix replaces the first suffix in rules that w ends with, if the stem measures above 0
+func replaceSuffix(w string, rules []porterSuffix) string {
+	for _, r := range rules {
+		if strings.HasSuffix(w, r.suffix) {
+			if stem := w[:len(w)-len(r.suffix)]; measure(stem) > 0 {
+				return stem + r.replacement
+			}
+			return w
+		}
+	}
+	return w
+}
+
+// porterStep4 drops the longest suffix in porterStep4Suffixes that w ends with, when the stem measures above 1. "ion"
+// is only dropped after "s" or "t".
+func porterStep4(w string) string {
+	var longest string
+	for _, suffix := range porterStep4Suffixes {
+		if strings.HasSuffix(w, suffix) && len(suffix) > len(longest) {
+			longest = suffix
+		}
+	}
+	if longest == "" {
+		return w
+	}
+	stem := w[:len(w)-len(longest)]
+	if measure(stem) <= 1 || (longest == "ion" && !strings.HasSuffix(stem, "s") && !strings.HasSuffix(stem, "t")) {
+		return w
+	}
+	return stem
+}
+
+// consonant reports whether w[i] is a consonant. "y" is one unless it follows a consonant.
+func consonant(w string, i int) bool {
+	switch w[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !consonant(w, i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences in w, m in [C](VC){m}[V]
+func measure(w string) int {
+	m, i := 0, 0
+	for i < len(w) && consonant(w, i) {
+		i++
+	}
+	for i < len(w) {
+		for i < len(w) && !consonant(w, i) {
+			i++
+		}
+		if i == len(w) {
+			break
+		}
+		for i < len(w) && consonant(w, i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+func hasVowel(w string) bool {
+	for i := range w {
+		if !consonant(w, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleConsonant reports whether w ends with two of the same consonant
+func doubleConsonant(w string) bool {
+	n := len(w)
+	return n >= 2 && w[n-1] == w[n-2] && consonant(w, n-1)
+}
+
+// cvc reports whether w ends consonant-vowel-consonant, where the last consonant isn't "w", "x" or "y"
+func cvc(w string) bool {
+	n := len(w)
+	return n >= 3 && consonant(w, n-3) && !consonant(w, n-2) && consonant(w, n-1) &&
+		!strings.ContainsRune("wxy", rune(w[n-1]))
+}
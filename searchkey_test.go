@@ -0,0 +1,49 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestSearchKey() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"  Crème Brûlée!  ":      "creme brulee",
+		"Don't   STOP—believing": "dont stop believing",
+		"Straße, Øresund":        "strasse oresund",
+		"iPhone 15 Pro (256GB)":  "iphone 15 pro 256gb",
+		"":                       "",
+	} {
+		assert.Equal(want, searchKey(in, false), in)
+	}
+	assert.Equal("run shoe for connect", searchKey("Running Shoes for Connections", true))
+
+	// examples from Porter's paper
+	for in, want := range map[string]string{
+		"caresses": "caress", "ponies": "poni", "ties": "ti", "caress": "caress", "cats": "cat",
+		"feed": "feed", "agreed": "agre", "plastered": "plaster", "bled": "bled", "motoring": "motor",
+		"sing": "sing", "conflated": "conflat", "troubled": "troubl", "sized": "size", "hopping": "hop",
+		"tanned": "tan", "falling": "fall", "hissing": "hiss", "fizzed": "fizz", "failing": "fail",
+		"filing": "file", "happy": "happi", "sky": "sky", "relational": "relat", "conditional": "condit",
+		"rational": "ration", "valenci": "valenc", "digitizer": "digit", "conformabli": "conform",
+		"generalization": "gener", "electrical": "electr", "hopeful": "hope", "goodness": "good",
+		"revival": "reviv", "allowance": "allow", "adjustment": "adjust", "adoption": "adopt",
+		"probate": "probat", "rate": "rate", "cease": "ceas", "controll": "control", "roll": "roll",
+		"Running": "Running", "ox": "ox",
+	} {
+		assert.Equal(want, porterStem(in), in)
+	}
+
+	var s struct {
+		Title string `conform:"trim"`
+		Key   string `conform:"searchkey=stem"`
+	}
+	s.Key = "Élégant Running Shoes"
+	assert.NoError(Strings(&s))
+	assert.Equal("eleg run shoe", s.Key)
+
+	_, err := Compile("searchkey=porter")
+	assert.EqualError(err, `searchkey=porter: "porter" is not "stem"`)
+}
@@ -0,0 +1,47 @@
+package conform
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tagAliases expand to a chain of tags maintained by the package, so fields using them pick up improvements
+var tagAliases = map[string]string{
+	// secure is a baseline for any user input: valid UTF-8, without control or bidi characters, trimmed last
+	// so nothing removed before it leaves whitespace at the ends
+	"secure": "validutf8,nocontrol,nobidi,trim",
+}
+
+// expandAliases replaces aliases in a chain of tags with the tags they stand for, and deprecated tags, including
+// those tried by "a|b", with the tags they were renamed to. A sanitizer added under an alias's name runs instead of
+// the alias.
+func (r *Registry) expandAliases(tags []string) []string {
+	var expanded []string
+	for _, tag := range tags {
+		alts := alternatives(tag)
+		for i, alt := range alts {
+			alts[i] = renameDeprecated(alt)
+		}
+		tag = strings.Join(alts, "|")
+		if _, own := r.sanitizer(tag); own {
+			expanded = append(expanded, tag)
+			continue
+		}
+		if chain, ok := tagAliases[tag]; ok {
+			expanded = append(expanded, r.expandAliases(SplitTags(chain))...)
+			continue
+		}
+		expanded = append(expanded, tag)
+	}
+	return expanded
+}
+
+// noControl removes control characters, other than tabs and newlines
+func noControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' {
+			return -1
+		}
+		return r
+	}, s)
+}
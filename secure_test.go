@@ -0,0 +1,40 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestSecure() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Comment string `conform:"secure"`
+		Name    string `conform:"secure,name"`
+	}
+	s.Comment = "\x00 hello‮\tworld\r\nbye\xff \x1b"
+	s.Name = " ⁦bob⁩ "
+	Strings(&s)
+
+	assert.Equal("hello\tworld\nbye�", s.Comment)
+	assert.Equal("Bob", s.Name)
+
+	p, err := Compile("secure,upper")
+	assert.NoError(err)
+	assert.Equal("validutf8,nocontrol,nobidi,trim,upper", p.String(), "A pipeline should show what secure stands for")
+
+	r := NewRegistry()
+	r.AddSanitizer("secure", func(s string) string { return "[" + s + "]" })
+	own := struct {
+		Comment string `conform:"secure"`
+	}{" hi "}
+	assert.NoError(StringsWithOptions(&own, Options{Registry: r}))
+	assert.Equal("[ hi ]", own.Comment, "A sanitizer named secure should run instead of the alias")
+}
+
+func (t *testSuite) TestNoControl() {
+	assert := assert.New(t.T())
+
+	assert.Equal("a\tb\nc", noControl("a\tb\r\nc\x07\u0085"))
+}
@@ -0,0 +1,33 @@
+package conform
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// StringsLocked conforms a struct that other goroutines read, such as a cached config, holding locker while it
+// does. Readers must hold the same lock, or its read lock when locker is a *sync.RWMutex.
+func StringsLocked(iface interface{}, locker sync.Locker) error {
+	locker.Lock()
+	defer locker.Unlock()
+	return Strings(iface)
+}
+
+// CopyOnWrite conforms a copy of the struct p points to and stores the copy in p, so readers that Load p see
+// either the old value or the conformed one, never one partway through, and need no lock. If p is stored to
+// meanwhile, the new value is copied and conformed instead. A nil p is left as it is.
+func CopyOnWrite[T any](p *atomic.Pointer[T]) error {
+	for {
+		old := p.Load()
+		if old == nil {
+			return nil
+		}
+		c, err := Copy(old)
+		if err != nil {
+			return err
+		}
+		if p.CompareAndSwap(old, c) {
+			return nil
+		}
+	}
+}
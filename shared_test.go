@@ -0,0 +1,66 @@
+package conform
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sharedConfig struct {
+	Name  string   `conform:"trim,upper"`
+	Hosts []string `conform:"trim,lower"`
+}
+
+func (t *testSuite) TestStringsLocked() {
+	assert := assert.New(t.T())
+
+	var mu sync.RWMutex
+	config := sharedConfig{Name: " app ", Hosts: []string{" A.example ", " B.example "}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mu.RLock()
+				_ = config.Name + config.Hosts[0]
+				mu.RUnlock()
+			}
+		}()
+	}
+	assert.NoError(StringsLocked(&config, &mu))
+	wg.Wait()
+	assert.Equal("APP", config.Name)
+	assert.Equal([]string{"a.example", "b.example"}, config.Hosts)
+}
+
+func (t *testSuite) TestCopyOnWrite() {
+	assert := assert.New(t.T())
+
+	var p atomic.Pointer[sharedConfig]
+	assert.NoError(CopyOnWrite(&p))
+	assert.Nil(p.Load())
+
+	old := &sharedConfig{Name: " app ", Hosts: []string{" A.example "}}
+	p.Store(old)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c := p.Load()
+				_ = c.Name + c.Hosts[0]
+			}
+		}()
+	}
+	assert.NoError(CopyOnWrite(&p))
+	wg.Wait()
+	assert.Equal("APP", p.Load().Name)
+	assert.Equal([]string{"a.example"}, p.Load().Hosts)
+	assert.Equal(" app ", old.Name, "The old value should be left untouched")
+	assert.Equal(" A.example ", old.Hosts[0])
+}
@@ -0,0 +1,56 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	nonHandle = regexp.MustCompile(`[^a-z0-9_]`)
+	mention   = regexp.MustCompile(`(^|\s+)@[\pL\pN_]+`)
+)
+
+// handle strips a leading "@", lowercases and removes anything but [a-z0-9_], truncating to max characters when max > 0
+func handle(s string, max int) string {
+	h := nonHandle.ReplaceAllLiteralString(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@")), "")
+	if max > 0 && len(h) > max {
+		h = h[:max]
+	}
+	return h
+}
+
+// mentionStrip removes @mentions from free text
+func mentionStrip(s string) string {
+	out := mention.ReplaceAllLiteralString(s, "")
+	if strings.HasPrefix(strings.TrimLeftFunc(s, unicode.IsSpace), "@") {
+		out = strings.TrimLeftFunc(out, unicode.IsSpace)
+	}
+	return out
+}
+
+var (
+	hashtag    = regexp.MustCompile(`#[\pL\pN_]+`)
+	nonHashtag = regexp.MustCompile(`[^\pL\pN_]`)
+)
+
+// hashtags extracts the #tags from free text into a lowercase, comma separated list without duplicates
+func hashtags(s string) string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, tag := range hashtag.FindAllString(s, -1) {
+		tag = normaliseHashtag(tag)
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return strings.Join(tags, ",")
+}
+
+// normaliseHashtag strips leading "#"s, lowercases and removes anything but letters, numbers and underscores
+func normaliseHashtag(s string) string {
+	return nonHashtag.ReplaceAllLiteralString(strings.ToLower(strings.TrimLeft(strings.TrimSpace(s), "#")), "")
+}
@@ -0,0 +1,67 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestHandle() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Handle    string `conform:"handle"`
+		Truncated string `conform:"handle=5"`
+		Unicode   string `conform:"handle"`
+	}
+
+	s.Handle = " @Lee.Benson "
+	s.Truncated = "@LeeBenson"
+	s.Unicode = "@josé_99"
+	Strings(&s)
+
+	assert.Equal("leebenson", s.Handle)
+	assert.Equal("leebe", s.Truncated)
+	assert.Equal("jos_99", s.Unicode)
+}
+
+func (t *testSuite) TestMentionStrip() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Leading string `conform:"mention_strip"`
+		Middle  string `conform:"mention_strip"`
+		Email   string `conform:"mention_strip"`
+	}
+
+	s.Leading = "@lee thanks for the tip @dave!"
+	s.Middle = "cc @lee_b and @dave on this"
+	s.Email = "mail lee@example.com"
+	Strings(&s)
+
+	assert.Equal("thanks for the tip!", s.Leading)
+	assert.Equal("cc and on this", s.Middle)
+	assert.Equal("mail lee@example.com", s.Email, "E-mail addresses aren't mentions")
+}
+
+func (t *testSuite) TestHashtags() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Text  string   `conform:"hashtags"`
+		None  string   `conform:"hashtags"`
+		Tags  []string `conform:"dive,hashtags"`
+		Other []string `conform:"hashtags"`
+	}
+
+	s.Text = "Loving #Go and #golang, #go! #日本"
+	s.None = "no tags here"
+	s.Tags = []string{"#Go", " GoLang ", "##rust-lang"}
+	s.Other = []string{"see #Go", "and #Rust"}
+	Strings(&s)
+
+	assert.Equal("go,golang,日本", s.Text)
+	assert.Equal("", s.None)
+	assert.Equal([]string{"go", "golang", "rustlang"}, s.Tags)
+	assert.Equal([]string{"go", "rust"}, s.Other)
+}
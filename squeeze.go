@@ -0,0 +1,41 @@
+package conform
+
+import (
+	"strings"
+	"unicode"
+)
+
+// squeeze collapses runs of a repeated character to one. Without chars, only runs of three or more are
+// collapsed, as doubled letters are common, and digits are left alone. With chars, runs of two or more of those
+// characters are collapsed.
+func squeeze(s, chars string) string {
+	min := 3
+	if chars != "" {
+		min = 2
+	}
+	buf := getRunes(s)
+	defer putRunes(buf)
+	rs := *buf
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(rs); {
+		j := i + 1
+		for j < len(rs) && rs[j] == rs[i] {
+			j++
+		}
+		r := rs[i]
+		n := j - i
+		squeezable := !unicode.IsDigit(r)
+		if chars != "" {
+			squeezable = strings.ContainsRune(chars, r)
+		}
+		if n >= min && squeezable {
+			n = 1
+		}
+		for k := 0; k < n; k++ {
+			b.WriteRune(r)
+		}
+		i = j
+	}
+	return b.String()
+}
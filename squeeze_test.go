@@ -0,0 +1,27 @@
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestSqueeze() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"soooo cool!!!!!": "so cool!",
+		"bookkeeper":      "bookkeeper",
+		"nooo   way?!?!":  "no way?!?!",
+		"1000000 points":  "1000000 points",
+		"ohhhh 😀😀😀😀":      "oh 😀",
+		"":                "",
+	} {
+		assert.Equal(want, squeeze(in, ""), in)
+	}
+
+	var s struct {
+		Text string `conform:"squeeze=!?,,trim"`
+	}
+	s.Text = " sooo good!! really?? yes,, "
+	assert.NoError(Strings(&s))
+	assert.Equal("sooo good! really? yes,", s.Text)
+}
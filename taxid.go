@@ -0,0 +1,91 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ein formats a US employer identification number as "12-3456789"
+func ein(s string) (string, error) {
+	d := onlyNumbers(s)
+	if len(d) != 9 || strings.Trim(s, "0123456789- ") != "" {
+		return s, fmt.Errorf("%q is not an EIN", s)
+	}
+	return d[:2] + "-" + d[2:], nil
+}
+
+// maskedSSN is what ssn=mask gives for values that aren't SSNs, so nothing of them is kept
+const maskedSSN = "***-**-****"
+
+// ssn formats a US social security number as "123-45-6789", or with mask, as "***-**-6789". Numbers that can't
+// have been issued, such as those starting 000, 666 or 9, are reported. With mask, they're masked entirely rather
+// than left as they are. Errors don't include the value, as it may be a real number written wrongly.
+func ssn(s string, mask bool) (string, error) {
+	d := onlyNumbers(s)
+	var err error
+	switch {
+	case len(d) != 9 || strings.Trim(s, "0123456789- ") != "":
+		err = errors.New("not an SSN, which has 9 digits")
+	case d[:3] == "000" || d[:3] == "666" || d[0] == '9':
+		err = errors.New("not an SSN, as no area number starts 000, 666 or 9")
+	case d[3:5] == "00" || d[5:] == "0000":
+		err = errors.New("not an SSN, as no group number is 00 and no serial number 0000")
+	}
+	if err != nil {
+		if mask {
+			return maskedSSN, err
+		}
+		return s, err
+	}
+	area, group, serial := d[:3], d[3:5], d[5:]
+	if mask {
+		return "***-**-" + serial, nil
+	}
+	return area + "-" + group + "-" + serial, nil
+}
+
+func ssnDirective(param string) (transform, error) {
+	if param != "" && param != "mask" {
+		return nil, fmt.Errorf("%q is not \"mask\"", param)
+	}
+	return func(s string) (string, error) { return ssn(s, param == "mask") }, nil
+}
+
+// vatPrefixes are the country prefixes VAT numbers start with, by region. Greece uses "EL" and Northern Ireland
+// "XI".
+var vatPrefixes = map[string][]string{
+	"EU": {"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "EL", "ES", "FI", "FR", "HR", "HU", "IE", "IT", "LT",
+		"LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK", "XI"},
+	"GB": {"GB"},
+}
+
+// vatID uppercases a VAT number and removes spaces, hyphens and dots, checking it starts with one of region's
+// country prefixes
+func vatID(s, region string) (string, error) {
+	c := strings.NewReplacer(" ", "", "-", "", ".", "").Replace(strings.ToUpper(strings.TrimSpace(s)))
+	for _, prefix := range vatPrefixes[strings.ToUpper(region)] {
+		if strings.HasPrefix(c, prefix) && len(c) > len(prefix)+1 && isAlphaNumeric(c[len(prefix):]) {
+			return c, nil
+		}
+	}
+	return s, fmt.Errorf("%q is not a VAT number", s)
+}
+
+func vatIDDirective(param string) (transform, error) {
+	if _, ok := vatPrefixes[strings.ToUpper(param)]; !ok {
+		return nil, fmt.Errorf("unknown region %q", param)
+	}
+	return func(s string) (string, error) { return vatID(s, param) }, nil
+}
+
+func isAlphaNumeric(s string) bool {
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestEIN() {
+	assert := assert.New(t.T())
+
+	for _, in := range []string{"123456789", "12-3456789", " 12 345 6789 "} {
+		out, err := ein(in)
+		assert.NoError(err, in)
+		assert.Equal("12-3456789", out, in)
+	}
+	for _, in := range []string{"12345678", "12-3456789a", ""} {
+		out, err := ein(in)
+		assert.Error(err, in)
+		assert.Equal(in, out)
+	}
+}
+
+func (t *testSuite) TestSSN() {
+	assert := assert.New(t.T())
+
+	out, err := ssn("123 45 6789", false)
+	assert.NoError(err)
+	assert.Equal("123-45-6789", out)
+
+	out, err = ssn("123456789", true)
+	assert.NoError(err)
+	assert.Equal("***-**-6789", out)
+
+	for _, in := range []string{"000-12-3456", "666-12-3456", "912-34-5678", "123-00-4567", "123-45-0000", "12345"} {
+		out, err := ssn(in, false)
+		assert.Error(err, in)
+		assert.NotContains(err.Error(), in, "Errors shouldn't include the value")
+		assert.Equal(in, out)
+
+		out, err = ssn(in, true)
+		assert.Error(err, in)
+		assert.Equal("***-**-****", out, "Masked values that aren't SSNs should be masked entirely")
+	}
+	_, err = ssn("12345678", false)
+	assert.EqualError(err, "not an SSN, which has 9 digits")
+	_, err = ssn("666-12-3456", false)
+	assert.EqualError(err, "not an SSN, as no area number starts 000, 666 or 9")
+
+	_, err = Compile("ssn=hide")
+	assert.EqualError(err, `ssn=hide: "hide" is not "mask"`)
+}
+
+func (t *testSuite) TestVATID() {
+	assert := assert.New(t.T())
+
+	for _, c := range []struct{ in, region, want string }{
+		{"de 123 456 789", "EU", "DE123456789"},
+		{"nl.1234.56.789.B01", "eu", "NL123456789B01"},
+		{"el-123456789", "EU", "EL123456789"},
+		{"gb 123 4567 89", "GB", "GB123456789"},
+	} {
+		out, err := vatID(c.in, c.region)
+		assert.NoError(err, c.in)
+		assert.Equal(c.want, out, c.in)
+	}
+
+	for _, c := range []struct{ in, region string }{
+		{"123456789", "EU"},
+		{"GB123456789", "EU"},
+		{"US123456789", "EU"},
+		{"DE", "EU"},
+		{"DE12345678!", "EU"},
+	} {
+		out, err := vatID(c.in, c.region)
+		assert.Error(err, c.in)
+		assert.Equal(c.in, out)
+	}
+
+	_, err := Compile("vatid=US")
+	assert.EqualError(err, `vatid=US: unknown region "US"`)
+}
@@ -0,0 +1,66 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+	"time"
+	// Go's copy of tzdata, so every name is checked against the same database wherever the tests run
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestTzName() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"America/Los_Angeles": "America/Los_Angeles",
+		"PST":                 "America/Los_Angeles",
+		"us/pacific":          "America/Los_Angeles",
+		" america/new york ":  "America/New_York",
+		"asia/calcutta":       "Asia/Kolkata",
+		"europe/london":       "Europe/London",
+		"utc":                 "Etc/UTC",
+		"EST":                 "America/New_York",
+		"pacific/ponape":      "Pacific/Pohnpei",
+		"Europe/Podgorica":    "Europe/Podgorica",
+		"Mars/Olympus_Mons":   "Mars/Olympus_Mons",
+	} {
+		assert.Equal(want, tzName(in), in)
+	}
+
+	RegisterTimeZoneAlias("Pacific Time (US & Canada)", "America/Los_Angeles")
+	assert.Equal("America/Los_Angeles", tzName("pacific time (us & canada)"))
+
+	// registering while names are being converted is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterTimeZoneAlias("Central European Time", "Europe/Berlin")
+	}()
+	tzName("central european time")
+	wg.Wait()
+	assert.Equal("Europe/Berlin", tzName("central european time"))
+}
+
+func (t *testSuite) TestTimeZonesLoad() {
+	assert := assert.New(t.T())
+
+	listed := map[string]bool{}
+	for _, name := range timeZones {
+		listed[name] = true
+		_, err := time.LoadLocation(name)
+		assert.NoError(err, "Every name should be one Go can load: %s", name)
+	}
+	for link, name := range timeZoneLinks {
+		assert.False(listed[link], "A link shouldn't be listed as a zone: %s", link)
+		assert.True(listed[name], "A link should be to a listed zone: %s", link)
+		_, err := time.LoadLocation(link)
+		assert.NoError(err, "Every link should be one Go can load: %s", link)
+	}
+	for alias, name := range timeZoneAliases {
+		assert.True(listed[name], "An alias should be for a listed zone: %s", alias)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// token cleans up an API key or token pasted by a user, trimming whitespace, surrounding quotes and a "Bearer "
+// prefix, but leaving the token itself, including any prefix such as "#" or "sk_", as it is. Tokens with
+// whitespace inside them are reported, without the token, as errors may be logged.
+func token(s string) (string, error) {
+	t := strings.TrimSpace(s)
+	for {
+		before := t
+		if n := len(t); n >= 2 && strings.ContainsRune("\"'`", rune(t[0])) && t[n-1] == t[0] {
+			t = strings.TrimSpace(t[1 : n-1])
+		}
+		if len(t) > 7 && strings.EqualFold(t[:7], "bearer ") {
+			t = strings.TrimSpace(t[7:])
+		}
+		if t == before {
+			break
+		}
+	}
+	if strings.IndexFunc(t, unicode.IsSpace) != -1 {
+		return s, errors.New("token has whitespace inside it")
+	}
+	return t, nil
+}
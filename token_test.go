@@ -0,0 +1,34 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestToken() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"  sk_live_abc123 \n":      "sk_live_abc123",
+		`"sk_live_abc123"`:         "sk_live_abc123",
+		"Bearer eyJhbGc.eyJzdWI.x": "eyJhbGc.eyJzdWI.x",
+		`bearer "abc"`:             "abc",
+		`'BEARER  abc'`:            "abc",
+		"#abc123":                  "#abc123",
+		"Bearer":                   "Bearer",
+		`"abc`:                     `"abc`,
+		"":                         "",
+	} {
+		out, err := token(in)
+		assert.NoError(err, in)
+		assert.Equal(want, out, in)
+	}
+
+	out, err := token("abc def")
+	assert.EqualError(err, "token has whitespace inside it", "Errors shouldn't include the token")
+	assert.Equal("abc def", out)
+
+	_, err = token("Token abc")
+	assert.Error(err, "Only Bearer prefixes should be stripped")
+}
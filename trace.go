@@ -0,0 +1,48 @@
+package conform
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// Tracer traces calls to Strings, StringsContext and StringsWithOptions, such as with OpenTelemetry spans made by
+// conformotel, to see what conforming costs in traces of hot endpoints
+type Tracer interface {
+	// Start is called before a value is conformed, with the name of its type. The func returned is called once
+	// it's done, with the number of tagged strings conformed, how many of them changed, and the error returned.
+	Start(ctx context.Context, typeName string) func(fields, changed int, err error)
+}
+
+// tracer is nil by default, so nothing is traced or counted
+var tracer Tracer
+
+// SetTracer traces every call to Strings and the functions like it with t, or stops tracing when t is nil. Set
+// it at startup, before anything is conformed.
+func SetTracer(t Tracer) {
+	tracer = t
+}
+
+// StringsContext conforms strings based on reflection tags, like Strings, tracing the call as part of ctx when a
+// Tracer is set
+func StringsContext(ctx context.Context, iface interface{}) error {
+	return stringsContext(ctx, iface, defaultOptions())
+}
+
+func stringsContext(ctx context.Context, iface interface{}, opts Options) error {
+	w := newWalker(opts)
+	if tracer == nil {
+		return w.conformStruct(iface, 0)
+	}
+	w.stats = &walkStats{}
+	end := tracer.Start(ctx, fmt.Sprintf("%T", iface))
+	err := w.conformStruct(iface, 0)
+	end(int(w.stats.fields.Load()), int(w.stats.changed.Load()), err)
+	return err
+}
+
+// walkStats counts the strings conformed in a call, for its trace. They're counted atomically, as slices of structs
+// can be conformed in parallel.
+type walkStats struct {
+	fields, changed atomic.Int64
+}
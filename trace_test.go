@@ -0,0 +1,61 @@
+package conform
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type traceKey struct{}
+
+type recordingTracer struct {
+	calls []tracedCall
+}
+
+type tracedCall struct {
+	ctx             context.Context
+	typeName        string
+	fields, changed int
+	err             error
+}
+
+func (r *recordingTracer) Start(ctx context.Context, typeName string) func(fields, changed int, err error) {
+	return func(fields, changed int, err error) {
+		r.calls = append(r.calls, tracedCall{ctx, typeName, fields, changed, err})
+	}
+}
+
+func (t *testSuite) TestTracer() {
+	assert := assert.New(t.T())
+
+	type item struct {
+		SKU string `conform:"trim,upper"`
+	}
+	type order struct {
+		Name  string `conform:"trim"`
+		Note  string
+		Items []item
+	}
+
+	r := &recordingTracer{}
+	SetTracer(r)
+	defer SetTracer(nil)
+
+	o := order{Name: "ok", Note: " left ", Items: []item{{SKU: " a "}, {SKU: "B"}, {SKU: "c"}}}
+	ctx := context.WithValue(context.Background(), traceKey{}, "request")
+	assert.NoError(StringsContext(ctx, &o))
+	assert.NoError(StringsWithOptions(&o, Options{Parallelism: 2}))
+	assert.ErrorIs(Strings(o), ErrNotPointer)
+	assert.NoError(StringsWithOptionsContext(ctx, &o, Options{}))
+
+	if assert.Len(r.calls, 4) {
+		assert.Equal("request", r.calls[0].ctx.Value(traceKey{}))
+		assert.Equal("*conform.order", r.calls[0].typeName)
+		assert.Equal(4, r.calls[0].fields, "Untagged strings shouldn't be counted")
+		assert.Equal(2, r.calls[0].changed)
+		assert.Equal(0, r.calls[1].changed)
+		assert.True(errors.Is(r.calls[2].err, ErrNotPointer))
+		assert.Equal("request", r.calls[3].ctx.Value(traceKey{}), "Options calls should join the context's trace")
+	}
+}
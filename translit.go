@@ -0,0 +1,102 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Transliterator romanizes text in another script, for the "translit=name" tag
+type Transliterator interface {
+	Transliterate(s string) string
+}
+
+// transliterators are those registered, keyed by lowercase name
+var transliterators = struct {
+	sync.RWMutex
+	m map[string]Transliterator
+}{m: map[string]Transliterator{}}
+
+func init() {
+	RegisterTransliterator("ru", russian)
+	RegisterTransliterator("el", greek)
+	RegisterTransliterator("zh-pinyin", pinyin{})
+}
+
+// RegisterTransliterator registers t for use with the "translit=name" tag, replacing any already registered
+// under name. "ru", "el" and "zh-pinyin" are built in.
+func RegisterTransliterator(name string, t Transliterator) {
+	transliterators.Lock()
+	transliterators.m[strings.ToLower(name)] = t
+	transliterators.Unlock()
+}
+
+// transliterate romanizes s with the transliterator registered under name. Like lookup tables, they're looked
+// up when applied, so they can be registered after the tag is first used.
+func transliterate(s, name string) (string, error) {
+	transliterators.RLock()
+	t, ok := transliterators.m[strings.ToLower(name)]
+	transliterators.RUnlock()
+	if !ok {
+		return s, fmt.Errorf("no transliterator registered as %q", name)
+	}
+	return t.Transliterate(s), nil
+}
+
+// TransliterationTable is a Transliterator that replaces lowercase letters, or pairs of them, with their
+// romanizations. Pairs are matched before single letters. Capitals are romanized as their lowercase letters
+// are, capitalized, or in capitals when the letters around them are capitals too.
+type TransliterationTable map[string]string
+
+// Transliterate romanizes s
+func (t TransliterationTable) Transliterate(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(rs); {
+		n := 2
+		out, ok := "", false
+		for ; n > 0 && !ok; n-- {
+			if i+n <= len(rs) {
+				out, ok = t[strings.ToLower(string(rs[i:i+n]))]
+			}
+		}
+		n++
+		if !ok {
+			b.WriteRune(rs[i])
+			i++
+			continue
+		}
+		if unicode.IsUpper(rs[i]) {
+			if (i+n < len(rs) && unicode.IsUpper(rs[i+n])) || (i > 0 && unicode.IsUpper(rs[i-1])) {
+				out = strings.ToUpper(out)
+			} else {
+				out = ucFirst(out)
+			}
+		}
+		b.WriteString(out)
+		i += n
+	}
+	return b.String()
+}
+
+// russian follows the scheme common in usernames and URLs, close to BGN/PCGN without diacritics
+var russian = TransliterationTable{
+	"а": "a", "б": "b", "в": "v", "г": "g", "д": "d", "е": "e", "ё": "e", "ж": "zh", "з": "z", "и": "i",
+	"й": "y", "к": "k", "л": "l", "м": "m", "н": "n", "о": "o", "п": "p", "р": "r", "с": "s", "т": "t",
+	"у": "u", "ф": "f", "х": "kh", "ц": "ts", "ч": "ch", "ш": "sh", "щ": "shch", "ъ": "", "ы": "y", "ь": "",
+	"э": "e", "ю": "yu", "я": "ya",
+}
+
+// greek follows ELOT 743, the Greek standard used for passports and road signs
+var greek = TransliterationTable{
+	"α": "a", "ά": "a", "β": "v", "γ": "g", "δ": "d", "ε": "e", "έ": "e", "ζ": "z", "η": "i", "ή": "i",
+	"θ": "th", "ι": "i", "ί": "i", "ϊ": "i", "ΐ": "i", "κ": "k", "λ": "l", "μ": "m", "ν": "n", "ξ": "x",
+	"ο": "o", "ό": "o", "π": "p", "ρ": "r", "σ": "s", "ς": "s", "τ": "t", "υ": "y", "ύ": "y", "ϋ": "y",
+	"ΰ": "y", "φ": "f", "χ": "ch", "ψ": "ps", "ω": "o", "ώ": "o",
+	"ου": "ou", "ού": "ou", "αυ": "av", "αύ": "av", "ευ": "ev", "εύ": "ev", "ηυ": "iv", "ηύ": "iv",
+	"γγ": "ng", "γξ": "nx", "γχ": "nch", "μπ": "mp", "ντ": "nt",
+}
@@ -0,0 +1,89 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type reverser struct{}
+
+func (reverser) Transliterate(s string) string {
+	rs := []rune(s)
+	for i, j := 0, len(rs)-1; i < j; i, j = i+1, j-1 {
+		rs[i], rs[j] = rs[j], rs[i]
+	}
+	return string(rs)
+}
+
+func (t *testSuite) TestTranslit() {
+	assert := assert.New(t.T())
+
+	for in, want := range map[string]string{
+		"Москва":        "Moskva",
+		"Щукин":         "Shchukin",
+		"ЖУКОВ":         "ZHUKOV",
+		"Юлия Ёлкина":   "Yuliya Elkina",
+		"объект, 2024":  "obekt, 2024",
+		"already latin": "already latin",
+	} {
+		out, err := transliterate(in, "ru")
+		assert.NoError(err)
+		assert.Equal(want, out, in)
+	}
+
+	for in, want := range map[string]string{
+		"Αθήνα":       "Athina",
+		"Θεσσαλονίκη": "Thessaloniki",
+		"ΕΥΑΓΓΕΛΟΣ":   "EVANGELOS",
+		"Ουρανός":     "Ouranos",
+		"ψυχή":        "psychi",
+	} {
+		out, err := transliterate(in, "el")
+		assert.NoError(err)
+		assert.Equal(want, out, in)
+	}
+
+	for in, want := range map[string]string{
+		"北京大学":     "bei jing da xue",
+		"李小龙":      "li xiao long",
+		"我爱Go语言":   "wo ai Go yu yan",
+		"上海, 2024": "shang hai, 2024",
+		"丟兙":       "diu 兙",
+	} {
+		out, err := transliterate(in, "zh-pinyin")
+		assert.NoError(err)
+		assert.Equal(want, out, in)
+	}
+
+	_, err := transliterate("abc", "nope")
+	assert.EqualError(err, `no transliterator registered as "nope"`)
+
+	var s struct {
+		Username string `conform:"translit=reversed,lower"`
+		Slug     string `conform:"translit=ru,slug"`
+		City     string `conform:"translit=zh-pinyin,slug"`
+	}
+	s.Username = "ABC"
+	s.Slug = "Новости Дня"
+	s.City = "北京"
+	// registering while fields are being transliterated is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterTransliterator("reversed", reverser{})
+	}()
+	transliterate("abc", "reversed")
+	wg.Wait()
+	defer delete(transliterators.m, "reversed")
+	assert.NoError(Strings(&s))
+	assert.Equal("cba", s.Username, "Transliterators should be looked up when applied")
+	assert.Equal("novosti-dnya", s.Slug)
+	assert.Equal("bei-jing", s.City)
+
+	_, err = Compile("translit")
+	assert.EqualError(err, "translit: missing transliterator name")
+}
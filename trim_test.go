@@ -0,0 +1,41 @@
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestTrimPunct() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Punct string `conform:"trimpunct"`
+		Chars string `conform:"trimchars=.,;:,upper"`
+		Comma string `conform:"trimchars=,,trim"`
+	}
+	s.Punct = `"...Hello, world!" `
+	s.Chars = ".,;hello, world:;."
+	s.Comma = ", hi ,"
+
+	assert.NoError(Strings(&s))
+	assert.Equal("Hello, world", s.Punct)
+	assert.Equal("HELLO, WORLD", s.Chars)
+	assert.Equal("hi", s.Comma)
+
+	_, err := Compile("trimchars=")
+	assert.Error(err)
+}
+
+func (t *testSuite) TestSplitTags() {
+	assert := assert.New(t.T())
+
+	for tags, want := range map[string][]string{
+		"trim,lower":                  {"trim", "lower"},
+		"trimchars=.,;:,upper":        {"trimchars=.,;:", "upper"},
+		"trimchars=,,trim":            {"trimchars=,", "trim"},
+		"wrap=72,!html":               {"wrap=72", "!html"},
+		"decimal_sep=comma,decimal=2": {"decimal_sep=comma", "decimal=2"},
+		"":                            {""},
+	} {
+		assert.Equal(want, SplitTags(tags), tags)
+	}
+}
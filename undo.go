@@ -0,0 +1,107 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Undo holds the original values of the strings StringsWithUndo changed, by field path, so they can be put back
+// with Revert, e.g. to echo the user's input back when validation fails
+type Undo struct {
+	Changes []FieldChange
+}
+
+// StringsWithUndo works like Strings, and also returns what it changed
+func StringsWithUndo(iface interface{}) (Undo, error) {
+	v := reflect.ValueOf(iface)
+	if v.Kind() != reflect.Ptr {
+		return Undo{}, Strings(iface)
+	}
+	before := deepCopy(v.Elem(), map[ptrKey]reflect.Value{})
+	err := Strings(iface)
+	return Undo{Changes: Diff(before.Interface(), v.Elem().Interface())}, err
+}
+
+// Revert puts back the original values recorded in undo
+func Revert(iface interface{}, undo Undo) error {
+	v := reflect.ValueOf(iface)
+	if v.Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: %T", ErrNotPointer, iface)
+	}
+	for _, c := range undo.Changes {
+		if !setPath(v.Elem(), c.Path, c.Before) {
+			return fmt.Errorf("%s: no such string", c.Path)
+		}
+	}
+	return nil
+}
+
+// setPath sets the string at path, as written by Diff, returning false if there isn't one
+func setPath(v reflect.Value, path, s string) bool {
+	switch v.Kind() {
+	case reflect.Ptr:
+		return !v.IsNil() && setPath(v.Elem(), path, s)
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return false
+		}
+		el := reflect.New(v.Elem().Type()).Elem()
+		el.Set(v.Elem())
+		if !setPath(el, path, s) {
+			return false
+		}
+		v.Set(el)
+		return true
+	}
+
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		if v.Kind() != reflect.String || !v.CanSet() {
+			return false
+		}
+		v.SetString(s)
+		return true
+	}
+
+	if path[0] != '[' {
+		end := strings.IndexAny(path, ".[")
+		if end == -1 {
+			end = len(path)
+		}
+		if v.Kind() != reflect.Struct {
+			return false
+		}
+		f := v.FieldByName(path[:end])
+		return f.IsValid() && setPath(f, path[end:], s)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		end := strings.Index(path, "]")
+		if end < 1 {
+			return false
+		}
+		i, err := strconv.Atoi(path[1:end])
+		if err != nil || i < 0 || i >= v.Len() {
+			return false
+		}
+		return setPath(v.Index(i), path[end+1:], s)
+	case reflect.Map:
+		// keys can contain "]", so match them whole
+		for _, k := range v.MapKeys() {
+			prefix := fmt.Sprintf("[%v]", k)
+			if !strings.HasPrefix(path, prefix) {
+				continue
+			}
+			el := reflect.New(v.Type().Elem()).Elem()
+			el.Set(v.MapIndex(k))
+			if setPath(el, path[len(prefix):], s) {
+				v.SetMapIndex(k, el)
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestUndo() {
+	assert := assert.New(t.T())
+
+	type Address struct {
+		City string `conform:"trim,title"`
+	}
+	type Form struct {
+		Name    string            `conform:"trim,name"`
+		Email   *string           `conform:"email"`
+		Tags    []string          `conform:"lower"`
+		Labels  map[string]string `conform:"upper"`
+		Homes   map[string]Address
+		Address Address
+		Age     int
+	}
+
+	email := " BOB@X.IO "
+	f := Form{
+		Name:    " bob ",
+		Email:   &email,
+		Tags:    []string{"A", "b"},
+		Labels:  map[string]string{"a]b": "x"},
+		Homes:   map[string]Address{"main": {City: " rome "}},
+		Address: Address{City: " paris "},
+	}
+
+	undo, err := StringsWithUndo(&f)
+	assert.NoError(err)
+	assert.Equal("Bob", f.Name)
+	assert.Equal("Paris", f.Address.City)
+	assert.Len(undo.Changes, 6)
+
+	f.Age = 42
+	assert.NoError(Revert(&f, undo))
+	assert.Equal(" bob ", f.Name)
+	assert.Equal(" BOB@X.IO ", *f.Email)
+	assert.Equal([]string{"A", "b"}, f.Tags)
+	assert.Equal(map[string]string{"a]b": "x"}, f.Labels)
+	assert.Equal(" rome ", f.Homes["main"].City)
+	assert.Equal(" paris ", f.Address.City)
+	assert.Equal(42, f.Age, "Only the strings that were conformed should be put back")
+
+	assert.Error(Revert(&f, Undo{Changes: []FieldChange{{Path: "Missing"}}}))
+	assert.Error(Revert(f, undo))
+}
@@ -0,0 +1,119 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// trackingParams are removed by the "url_notracking" tag. A trailing "*" matches any suffix.
+var trackingParams = struct {
+	sync.RWMutex
+	params []string
+}{params: []string{"utm_*", "fbclid", "gclid", "dclid", "msclkid", "mc_cid", "mc_eid", "igshid", "yclid"}}
+
+// RegisterTrackingParams adds query parameters to those removed by the "url_notracking" tag.
+// A trailing "*" matches any suffix, e.g. "pk_*".
+func RegisterTrackingParams(params ...string) {
+	trackingParams.Lock()
+	trackingParams.params = append(trackingParams.params, params...)
+	trackingParams.Unlock()
+}
+
+func isTrackingParam(key string) bool {
+	key = strings.ToLower(key)
+	trackingParams.RLock()
+	defer trackingParams.RUnlock()
+	for _, p := range trackingParams.params {
+		p = strings.ToLower(p)
+		if strings.HasSuffix(p, "*") && strings.HasPrefix(key, strings.TrimSuffix(p, "*")) || key == p {
+			return true
+		}
+	}
+	return false
+}
+
+// urlNoTracking removes tracking query parameters from a URL, keeping the order of the others
+func urlNoTracking(s string) string {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.RawQuery == "" {
+		return s
+	}
+	var kept []string
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		key := strings.SplitN(pair, "=", 2)[0]
+		if k, err := url.QueryUnescape(key); err == nil {
+			key = k
+		}
+		if !isTrackingParam(key) {
+			kept = append(kept, pair)
+		}
+	}
+	u.RawQuery = strings.Join(kept, "&")
+	u.ForceQuery = false
+	return u.String()
+}
+
+// urlSameHost blanks URLs that point to a host other than the allowed ones, so they can't be used as open
+// redirects. Relative URLs are kept. hosts is a ";" separated list, where "*.example.com" matches any subdomain.
+func urlSameHost(s, hosts string) string {
+	trimmed := strings.TrimSpace(s)
+	// browsers treat backslashes like forward slashes, so "/\evil.com" is protocol relative
+	if strings.HasPrefix(trimmed, "\\") || strings.HasPrefix(trimmed, "/\\") {
+		return ""
+	}
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return ""
+	}
+	if u.Scheme == "" && u.Host == "" && u.Opaque == "" {
+		return s
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range strings.Split(strings.ToLower(hosts), ";") {
+		if host == allowed || strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return s
+		}
+	}
+	return ""
+}
+
+// sharedAddressSpace is the carrier-grade NAT range from RFC 6598, which netip doesn't count as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// urlPublic blanks URLs whose host is a literal private, loopback, link-local or otherwise non-public IP address,
+// or "localhost". Hostnames aren't resolved, so it's a first line of defence against SSRF rather than the only one.
+func urlPublic(s string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("%q is not an absolute URL", s)
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "", fmt.Errorf("%q is not a public URL", s)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		// some resolvers accept IPv4 addresses in decimal, hex or octal, e.g. "2130706433" or "0x7f.1"
+		if numericHost.MatchString(host) {
+			return "", fmt.Errorf("%q is not a public URL", s)
+		}
+		return s, nil
+	}
+	addr = addr.Unmap()
+	if addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return "", fmt.Errorf("%q is not a public URL", s)
+	}
+	return s, nil
+}
+
+var numericHost = regexp.MustCompile(`^(0x[0-9a-f]*|[0-9]+)(\.(0x[0-9a-f]*|[0-9]+))*$`)
@@ -0,0 +1,115 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestURLNoTracking() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		"https://example.com/?id=1&utm_source=news&fbclid=abc": "https://example.com/?id=1",
+		"https://example.com/p?UTM_Medium=x&b=2&a=1#top":       "https://example.com/p?b=2&a=1#top",
+		"https://example.com/?gclid=1":                         "https://example.com/",
+		"https://example.com/?q=utm_source":                    "https://example.com/?q=utm_source",
+		"https://example.com/path":                             "https://example.com/path",
+		"not a url with %zz":                                   "not a url with %zz",
+		"https://example.com/?ref=twitter&utm_campaign=launch": "https://example.com/?ref=twitter",
+	} {
+		var s struct {
+			Link string `conform:"url_notracking"`
+		}
+		s.Link = in
+		Strings(&s)
+		assert.Equal(expected, s.Link, "url_notracking of %q", in)
+	}
+
+	RegisterTrackingParams("ref")
+	var s struct {
+		Link string `conform:"url_notracking"`
+	}
+	s.Link = "https://example.com/?ref=twitter&id=2"
+	Strings(&s)
+	assert.Equal("https://example.com/?id=2", s.Link, "Registered params should be removed")
+
+	// registering while URLs are being conformed is safe, for go test -race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterTrackingParams("pk_*")
+	}()
+	urlNoTracking("https://example.com/?pk_campaign=x")
+	wg.Wait()
+	assert.Equal("https://example.com/", urlNoTracking("https://example.com/?pk_campaign=x"))
+}
+
+func (t *testSuite) TestURLSameHost() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		"/account?tab=1":                        "/account?tab=1",
+		"account":                               "account",
+		"https://example.com/a":                 "https://example.com/a",
+		"http://EXAMPLE.com:8080/a":             "http://EXAMPLE.com:8080/a",
+		"https://www.example.org/":              "https://www.example.org/",
+		"https://example.org/":                  "",
+		"https://evil.com/":                     "",
+		"https://example.com.evil/":             "",
+		"//evil.com/path":                       "",
+		"/\\evil.com":                           "",
+		"javascript:alert(1)":                   "",
+		"mailto:lee@example.com":                "",
+		"https://evil.com/?https://example.com": "",
+	} {
+		var s struct {
+			Next string `conform:"url_samehost=example.com;*.example.org"`
+		}
+		s.Next = in
+		Strings(&s)
+		assert.Equal(expected, s.Next, "url_samehost of %q", in)
+	}
+}
+
+func (t *testSuite) TestURLPublic() {
+	assert := assert.New(t.T())
+
+	for in, expected := range map[string]string{
+		"https://hooks.example.com/x":             "https://hooks.example.com/x",
+		"https://8.8.8.8/dns":                     "https://8.8.8.8/dns",
+		"http://127.0.0.1:8080/":                  "",
+		"http://10.1.2.3/":                        "",
+		"http://192.168.1.1/":                     "",
+		"http://100.64.0.1/":                      "",
+		"http://169.254.169.254/latest/meta-data": "",
+		"http://[::1]/":                           "",
+		"http://[fe80::1]/":                       "",
+		"http://[::ffff:10.0.0.1]/":               "",
+		"http://0.0.0.0/":                         "",
+		"http://localhost:3000/":                  "",
+		"http://api.localhost/":                   "",
+		"http://2130706433/":                      "",
+		"http://0x7f.1/":                          "",
+		"/relative/path":                          "",
+	} {
+		var s struct {
+			Webhook string `conform:"url_public"`
+		}
+		s.Webhook = in
+		Strings(&s)
+		assert.Equal(expected, s.Webhook, "url_public of %q", in)
+	}
+
+	Strict = true
+	defer func() { Strict = false }()
+
+	var s struct {
+		Webhook string `conform:"url_public"`
+	}
+	s.Webhook = "http://127.0.0.1/"
+	assert.EqualError(Strings(&s), `Webhook: url_public: "http://127.0.0.1/" is not a public URL`)
+}
@@ -0,0 +1,176 @@
+//go:build !conform_minimal
+
+package conform
+
+// usStates maps US state and territory names, abbreviations and common misspellings to their two-letter USPS codes
+var usStates = map[string]string{
+	"AL": "AL",
+	"AK": "AK",
+	"AZ": "AZ",
+	"AR": "AR",
+	"CA": "CA",
+	"CO": "CO",
+	"CT": "CT",
+	"DE": "DE",
+	"DC": "DC",
+	"FL": "FL",
+	"GA": "GA",
+	"HI": "HI",
+	"ID": "ID",
+	"IL": "IL",
+	"IN": "IN",
+	"IA": "IA",
+	"KS": "KS",
+	"KY": "KY",
+	"LA": "LA",
+	"ME": "ME",
+	"MD": "MD",
+	"MA": "MA",
+	"MI": "MI",
+	"MN": "MN",
+	"MS": "MS",
+	"MO": "MO",
+	"MT": "MT",
+	"NE": "NE",
+	"NV": "NV",
+	"NH": "NH",
+	"NJ": "NJ",
+	"NM": "NM",
+	"NY": "NY",
+	"NC": "NC",
+	"ND": "ND",
+	"OH": "OH",
+	"OK": "OK",
+	"OR": "OR",
+	"PA": "PA",
+	"RI": "RI",
+	"SC": "SC",
+	"SD": "SD",
+	"TN": "TN",
+	"TX": "TX",
+	"UT": "UT",
+	"VT": "VT",
+	"VA": "VA",
+	"WA": "WA",
+	"WV": "WV",
+	"WI": "WI",
+	"WY": "WY",
+	"AS": "AS",
+	"GU": "GU",
+	"MP": "MP",
+	"PR": "PR",
+	"VI": "VI",
+
+	"Alabama":                  "AL",
+	"Alaska":                   "AK",
+	"Arizona":                  "AZ",
+	"Arkansas":                 "AR",
+	"California":               "CA",
+	"Colorado":                 "CO",
+	"Connecticut":              "CT",
+	"Delaware":                 "DE",
+	"District of Columbia":     "DC",
+	"Florida":                  "FL",
+	"Georgia":                  "GA",
+	"Hawaii":                   "HI",
+	"Idaho":                    "ID",
+	"Illinois":                 "IL",
+	"Indiana":                  "IN",
+	"Iowa":                     "IA",
+	"Kansas":                   "KS",
+	"Kentucky":                 "KY",
+	"Louisiana":                "LA",
+	"Maine":                    "ME",
+	"Maryland":                 "MD",
+	"Massachusetts":            "MA",
+	"Michigan":                 "MI",
+	"Minnesota":                "MN",
+	"Mississippi":              "MS",
+	"Missouri":                 "MO",
+	"Montana":                  "MT",
+	"Nebraska":                 "NE",
+	"Nevada":                   "NV",
+	"New Hampshire":            "NH",
+	"New Jersey":               "NJ",
+	"New Mexico":               "NM",
+	"New York":                 "NY",
+	"North Carolina":           "NC",
+	"North Dakota":             "ND",
+	"Ohio":                     "OH",
+	"Oklahoma":                 "OK",
+	"Oregon":                   "OR",
+	"Pennsylvania":             "PA",
+	"Rhode Island":             "RI",
+	"South Carolina":           "SC",
+	"South Dakota":             "SD",
+	"Tennessee":                "TN",
+	"Texas":                    "TX",
+	"Utah":                     "UT",
+	"Vermont":                  "VT",
+	"Virginia":                 "VA",
+	"Washington":               "WA",
+	"West Virginia":            "WV",
+	"Wisconsin":                "WI",
+	"Wyoming":                  "WY",
+	"American Samoa":           "AS",
+	"Guam":                     "GU",
+	"Northern Mariana Islands": "MP",
+	"Puerto Rico":              "PR",
+	"U.S. Virgin Islands":      "VI",
+
+	// abbreviations and misspellings
+	"Washington DC":   "DC",
+	"Washington D.C.": "DC",
+	"Virgin Islands":  "VI",
+	"Calif":           "CA",
+	"Cali":            "CA",
+	"Mass":            "MA",
+	"Penn":            "PA",
+	"Penna":           "PA",
+	"Wash":            "WA",
+	"Conn":            "CT",
+	"Tenn":            "TN",
+	"Fla":             "FL",
+	"Ill":             "IL",
+	"Wisc":            "WI",
+	"Minn":            "MN",
+	"Mich":            "MI",
+	"Ariz":            "AZ",
+	"Colo":            "CO",
+	"Okla":            "OK",
+	"Nebr":            "NE",
+	"Conneticut":      "CT",
+	"Connecticutt":    "CT",
+	"Massachusets":    "MA",
+	"Massachussetts":  "MA",
+	"Massachusettes":  "MA",
+	"Pensylvania":     "PA",
+	"Pennsilvania":    "PA",
+	"Missisippi":      "MS",
+	"Mississipi":      "MS",
+	"Misissippi":      "MS",
+	"Tennesee":        "TN",
+	"Tennesse":        "TN",
+	"Louisianna":      "LA",
+	"Lousiana":        "LA",
+	"Arizonia":        "AZ",
+	"Flordia":         "FL",
+	"Floria":          "FL",
+	"Ilinois":         "IL",
+	"Illinios":        "IL",
+	"Minesota":        "MN",
+	"Missourri":       "MO",
+	"Virgina":         "VA",
+	"West Virgina":    "WV",
+	"Wisconson":       "WI",
+	"Oaklahoma":       "OK",
+	"Kentuky":         "KY",
+	"Hawai":           "HI",
+	"Hawaiʻi":         "HI",
+	"Nevade":          "NV",
+	"Colorodo":        "CO",
+}
+
+func init() {
+	RegisterLookup("usstate", usStates)
+}
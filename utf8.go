@@ -0,0 +1,17 @@
+package conform
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// validUTF8 replaces each run of invalid bytes with U+FFFD, or drops them
+func validUTF8(s string, drop bool) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	if drop {
+		return strings.ToValidUTF8(s, "")
+	}
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
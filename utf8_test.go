@@ -0,0 +1,48 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestValidUTF8() {
+	assert := assert.New(t.T())
+
+	var s struct {
+		Replace string `conform:"validutf8"`
+		Drop    string `conform:"validutf8=drop"`
+		Valid   string `conform:"validutf8"`
+	}
+	s.Replace = "caf\xe9 \xff\xfeok"
+	s.Drop = "caf\xe9 \xff\xfeok"
+	s.Valid = "café"
+
+	assert.NoError(Strings(&s))
+	assert.Equal("caf� �ok", s.Replace)
+	assert.Equal("caf ok", s.Drop)
+	assert.Equal("café", s.Valid)
+
+	_, err := Compile("validutf8=skip")
+	assert.Error(err)
+}
+
+func (t *testSuite) TestBuiltinsEmitValidUTF8() {
+	assert := assert.New(t.T())
+
+	garbage := "  \xffJoHn \xc3 o'\xe2\x82 ROURKE\xf0  "
+	for _, tags := range []string{"trim", "ltrim", "rtrim", "lower", "upper", "title", "camel", "snake", "slug", "ucfirst", "name", "email", "alpha", "!num", "wrap=4", "excerpt=8", "nomarkdown", "dive,hashtags"} {
+		p, err := Compile(tags)
+		if assert.NoError(err) {
+			out, _ := p.Apply(garbage)
+			assert.True(utf8.ValidString(out), "%s gave %q", tags, out)
+		}
+	}
+
+	AddSanitizer("passthrough", func(s string) string { return s })
+	p, _ := Compile("passthrough")
+	out, _ := p.Apply(garbage)
+	assert.Equal(garbage, out, "Custom sanitizers should be left to do as they please")
+}
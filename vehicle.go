@@ -0,0 +1,75 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	vinChars = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)
+	gbPlate  = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z]{3}$`)
+	frPlate  = regexp.MustCompile(`^[A-Z]{2}[0-9]{3}[A-Z]{2}$`)
+	esPlate  = regexp.MustCompile(`^[0-9]{4}[A-Z]{3}$`)
+)
+
+// vin uppercases a vehicle identification number and removes spaces and hyphens. VINs never use I, O or Q, to
+// avoid confusion with 1 and 0, so those are read as the digits they were likely meant to be, and reported.
+func vin(s string) (string, error) {
+	c := stripCode(s)
+	if len(c) != 17 {
+		return s, fmt.Errorf("%q is not a VIN", s)
+	}
+	fixed := strings.NewReplacer("I", "1", "O", "0", "Q", "0").Replace(c)
+	if !vinChars.MatchString(fixed) {
+		return s, fmt.Errorf("%q is not a VIN", s)
+	}
+	if fixed != c {
+		return fixed, fmt.Errorf("%q has I, O or Q, which VINs don't use", s)
+	}
+	return c, nil
+}
+
+// plateFormats maps a region to a func that formats an uppercased number plate with spaces, hyphens and dots
+// removed
+var plateFormats = map[string]func(string) string{
+	"US": func(s string) string { return s },
+	"GB": func(s string) string {
+		// current plates are two letters and two digits for the area and age, then three random letters
+		if gbPlate.MatchString(s) {
+			return s[:4] + " " + s[4:]
+		}
+		return s
+	},
+	"FR": func(s string) string {
+		if frPlate.MatchString(s) {
+			return s[:2] + "-" + s[2:5] + "-" + s[5:]
+		}
+		return s
+	},
+	"IT": func(s string) string { return s },
+	"ES": func(s string) string {
+		if esPlate.MatchString(s) {
+			return s[:4] + " " + s[4:]
+		}
+		return s
+	},
+}
+
+func plateDirective(param string) (transform, error) {
+	if _, ok := plateFormats[strings.ToUpper(param)]; !ok {
+		return nil, fmt.Errorf("unknown region %q", param)
+	}
+	return func(s string) (string, error) { return plate(s, param), nil }, nil
+}
+
+// plate uppercases a number plate and writes it the way it's written in region
+func plate(s, region string) string {
+	f, ok := plateFormats[strings.ToUpper(region)]
+	if !ok {
+		return s
+	}
+	return f(strings.NewReplacer(" ", "", "-", "", ".", "").Replace(strings.ToUpper(strings.TrimSpace(s))))
+}
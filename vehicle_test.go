@@ -0,0 +1,55 @@
+//go:build !conform_minimal
+
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestVIN() {
+	assert := assert.New(t.T())
+
+	out, err := vin(" 1hgcm82633a-004352 ")
+	assert.NoError(err)
+	assert.Equal("1HGCM82633A004352", out)
+
+	out, err = vin("1HGCM82633AOO4352")
+	assert.EqualError(err, `"1HGCM82633AOO4352" has I, O or Q, which VINs don't use`)
+	assert.Equal("1HGCM82633A004352", out, "I, O and Q should be read as digits")
+
+	for _, in := range []string{"1HGCM82633A00435", "1HGCM82633A00435!", ""} {
+		out, err := vin(in)
+		assert.Error(err, in)
+		assert.Equal(in, out)
+	}
+
+	var s struct {
+		VIN string `conform:"vin"`
+	}
+	s.VIN = "1hgcm82633aoo4352"
+	assert.NoError(Strings(&s))
+	assert.Equal("1HGCM82633A004352", s.VIN)
+
+	s.VIN = "1hgcm82633aoo4352"
+	assert.Error(StringsWithOptions(&s, Options{Strict: true}), "I, O and Q should be an error in Strict mode")
+}
+
+func (t *testSuite) TestPlate() {
+	assert := assert.New(t.T())
+
+	for _, c := range []struct{ in, region, want string }{
+		{"ab12cde", "GB", "AB12 CDE"},
+		{" ab-12 cde", "gb", "AB12 CDE"},
+		{"a123 bcd", "GB", "A123BCD"},
+		{"ab 123 cd", "FR", "AB-123-CD"},
+		{"1234bcd", "ES", "1234 BCD"},
+		{"ab 123 cd", "IT", "AB123CD"},
+		{"7-abc-123", "US", "7ABC123"},
+		{"7abc123", "XX", "7abc123"},
+	} {
+		assert.Equal(c.want, plate(c.in, c.region), c.in)
+	}
+
+	_, err := Compile("plate=XX")
+	assert.EqualError(err, `plate=XX: unknown region "XX"`)
+}
@@ -0,0 +1,81 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ruleVersions are the rules registered with RegisterRuleVersion, keyed by version
+var ruleVersions = struct {
+	sync.RWMutex
+	m map[string]Rules
+}{m: map[string]Rules{}}
+
+// RegisterRuleVersion registers the chains of tags fields were conformed with under version, keyed by field name
+// as in Rules. Structs marked with a blank field tagged with the version, such as
+//
+//	_ struct{} `conform:"version=v2"`
+//
+// or every struct when Options.RuleVersion is set, are conformed with the version's rules instead of their
+// fields' tags, leaving fields without a rule alone. Data stored long ago can then be re-conformed under the rules
+// it was written with, such as during migrations, while the tags on fields move on.
+func RegisterRuleVersion(version string, rules Rules) {
+	if rules == nil {
+		rules = Rules{}
+	}
+	ruleVersions.Lock()
+	ruleVersions.m[version] = rules
+	ruleVersions.Unlock()
+	// orders found under the version's old rules may put fields in the wrong place
+	fieldOrders.Range(func(k, _ interface{}) bool {
+		if k.(fieldOrderKey).version == version {
+			fieldOrders.Delete(k)
+		}
+		return true
+	})
+}
+
+func ruleVersion(version string) (Rules, bool) {
+	ruleVersions.RLock()
+	defer ruleVersions.RUnlock()
+	rules, ok := ruleVersions.m[version]
+	return rules, ok
+}
+
+// versionMarker returns the version a struct of type t is marked with, if any
+func versionMarker(t reflect.Type, tagName string) string {
+	for i := 0; i < t.NumField(); i++ {
+		if version, ok := markedVersion(t.Field(i), tagName); ok {
+			return version
+		}
+	}
+	return ""
+}
+
+// markedVersion returns the version f marks its struct with, if it's a blank field tagged "version=..."
+func markedVersion(f reflect.StructField, tagName string) (string, bool) {
+	if f.Name != "_" {
+		return "", false
+	}
+	return strings.CutPrefix(f.Tag.Get(tagName), "version=")
+}
+
+// versioned returns a walker for the fields of a struct of type t, which follows the rules for the version in
+// Options, or the version t is marked with. Without either, it follows the fields' tags.
+func (w walker) versioned(t reflect.Type) (walker, error) {
+	w.version, w.rules = w.opts.RuleVersion, nil
+	if w.version == "" {
+		w.version = versionMarker(t, w.opts.TagName)
+	}
+	if w.version == "" {
+		return w, nil
+	}
+	rules, ok := ruleVersion(w.version)
+	if !ok {
+		return w, fmt.Errorf("%v: unknown rule version %q", t, w.version)
+	}
+	w.rules = rules
+	return w, nil
+}
@@ -0,0 +1,68 @@
+package conform
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *testSuite) TestRuleVersions() {
+	assert := assert.New(t.T())
+
+	RegisterRuleVersion("v1", Rules{"Email": "trim", "Name": "trim,upper"})
+	defer func() {
+		ruleVersions.Lock()
+		delete(ruleVersions.m, "v1")
+		ruleVersions.Unlock()
+	}()
+
+	type user struct {
+		Email string `conform:"trim,lower"`
+		Name  string `conform:"trim"`
+		Note  string `conform:"trim"`
+	}
+	type userV1 struct {
+		_     struct{} `conform:"version=v1"`
+		Email string   `conform:"trim,lower"`
+		Name  string   `conform:"trim"`
+		Note  string   `conform:"trim"`
+		User  user
+	}
+
+	u := userV1{Email: " A@B.C ", Name: " ann ", Note: " hi ", User: user{Email: " A@B.C ", Name: " ann "}}
+	assert.NoError(Strings(&u))
+	assert.Equal("A@B.C", u.Email, "Marked structs should follow their version's rules")
+	assert.Equal("ANN", u.Name)
+	assert.Equal(" hi ", u.Note, "Fields without a rule should be left alone")
+	assert.Equal("a@b.c", u.User.Email, "Nested structs should follow their own tags")
+	assert.Equal("ann", u.User.Name)
+
+	plain := user{Email: " A@B.C ", Name: " ann ", Note: " hi "}
+	assert.NoError(StringsWithOptions(&plain, Options{RuleVersion: "v1"}))
+	assert.Equal(user{Email: "A@B.C", Name: "ANN", Note: " hi "}, plain)
+
+	plans, err := Describe(userV1{})
+	assert.NoError(err)
+	assert.Equal([]FieldPlan{
+		{Path: "Email", JSONPath: "Email", Tags: []string{"trim"}},
+		{Path: "Name", JSONPath: "Name", Tags: []string{"trim", "upper"}},
+		{Path: "User.Email", JSONPath: "User.Email", Tags: []string{"trim", "lower"}},
+		{Path: "User.Name", JSONPath: "User.Name", Tags: []string{"trim"}},
+		{Path: "User.Note", JSONPath: "User.Note", Tags: []string{"trim"}},
+	}, plans)
+
+	u = userV1{Email: " A@B.C ", Name: " ann "}
+	assert.NoError(Fields(&u, "Email"))
+	assert.Equal("A@B.C", u.Email, "Fields should follow the version's rules")
+	assert.Equal(" ann ", u.Name)
+	plain = user{Email: " A@B.C "}
+	assert.NoError(FieldsWithOptions(&plain, Options{RuleVersion: "v1"}, "Email"))
+	assert.Equal("A@B.C", plain.Email)
+
+	RegisterRuleVersion("v1", Rules{"Email": "trim", "Name": "trim,upper"})
+	fieldOrders.Range(func(k, _ interface{}) bool {
+		assert.NotEqual("v1", k.(fieldOrderKey).version, "Registering a version again should drop orders found under it")
+		return true
+	})
+
+	err = StringsWithOptions(&plain, Options{RuleVersion: "v9"})
+	assert.EqualError(err, `conform.user: unknown rule version "v9"`)
+}
@@ -0,0 +1,423 @@
+package conform
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Structs are conformed using a stack of frames rather than by recursion, so values nested however deeply, such as
+// adversarial JSON decoded into recursive types, can't overflow the goroutine's stack.
+
+// frame is a struct, or the structs in a slice or map, on the stack of values being conformed
+type frame struct {
+	// w locates the struct, or the field holding the slice or map
+	w walker
+	// depth is how deeply the struct is nested, or the struct holding the slice or map
+	depth int
+
+	// ptr points to the struct, when the frame is one
+	ptr   interface{}
+	val   reflect.Value
+	order []int
+	// field is the position in order of the field being conformed
+	field int
+	// pushed is set when the field being conformed holds the frame above this one, so is done once that's popped
+	pushed bool
+
+	// elems is the slice or map of structs, when the frame is one
+	elems reflect.Value
+	keys  []reflect.Value
+	index int
+	// scratch is the copy of the map value being conformed, set back in the map once it's done. Copies aren't
+	// pooled, as hooks and func tags are given pointers to them, which they may keep.
+	scratch reflect.Value
+}
+
+// conformStruct conforms the struct iface points to, or the structs in the slice or map it points to, which are
+// conformed as if each had been passed on its own
+func (w walker) conformStruct(iface interface{}, depth int) error {
+	f, ok, err := w.structFrame(iface, depth)
+	if !ok && err == nil {
+		f, ok, err = w.elementsFrame(iface, depth)
+	}
+	if !ok && err == nil && w.opts.StrictRoot {
+		return fmt.Errorf("%w: %T", ErrUnsupportedRoot, iface)
+	}
+	if !ok {
+		return err
+	}
+	return walk(f)
+}
+
+// elementsFrame returns the frame for the structs in the slice or map iface points to, or false if it points to
+// something else. The structs are at depth, as the slice or map is held by nothing.
+func (w walker) elementsFrame(iface interface{}, depth int) (frame, bool, error) {
+	val := reflect.ValueOf(iface).Elem()
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Map {
+		return frame{}, false, nil
+	}
+	el := val.Type().Elem()
+	if el.Kind() == reflect.Ptr {
+		el = el.Elem()
+	}
+	if el.Kind() != reflect.Struct {
+		return frame{}, false, nil
+	}
+	if val.Kind() == reflect.Map {
+		return frame{w: w, depth: depth - 1, elems: val, keys: sortedKeys(val)}, true, nil
+	}
+	if w.parallel(val) {
+		return frame{}, false, w.conformElements(val, depth-1)
+	}
+	return frame{w: w, depth: depth - 1, elems: val}, true, nil
+}
+
+// conformField conforms a field of a struct, and any structs in it
+func (w walker) conformField(v reflect.StructField, el reflect.Value, depth int) error {
+	f, ok, err := w.field(v, el, depth)
+	if !ok {
+		return err
+	}
+	return walk(f)
+}
+
+// walk conforms root and everything nested in it, depth first. Hooks for a struct run once everything in it has
+// been conformed. A struct already on the stack, reached again through a cycle such as a pointer back to its
+// parent, is left to the frame conforming it.
+func walk(root frame) error {
+	stack := []frame{root}
+	onStack := map[ptrKey]bool{root.key(): true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		child, ok, err := top.next()
+		if err != nil {
+			return unwind(stack, err)
+		}
+		if ok {
+			if key := child.key(); key.t == nil || !onStack[key] {
+				onStack[key] = true
+				stack = append(stack, child)
+			}
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		delete(onStack, top.key())
+		if top.ptr != nil {
+			if err := runTypeHooks(top.ptr); err != nil {
+				return unwind(stack, err)
+			}
+		}
+	}
+	return nil
+}
+
+// key identifies the struct the frame conforms, or is the zero ptrKey for a slice or map
+func (f *frame) key() ptrKey {
+	if f.ptr == nil {
+		return ptrKey{}
+	}
+	return ptrKey{f.val.UnsafeAddr(), f.val.Type()}
+}
+
+// unwind prefixes err with the fields being conformed in the frames on the stack, outermost first
+func unwind(stack []frame, err error) error {
+	for i := len(stack) - 1; i >= 0; i-- {
+		f := &stack[i]
+		if f.ptr != nil && f.field < len(f.order) {
+			err = fmt.Errorf("%s: %w", f.val.Type().Field(f.order[f.field]).Name, err)
+		}
+	}
+	return err
+}
+
+// structFrame returns the frame for the struct iface points to, or false if it points to something else
+func (w walker) structFrame(iface interface{}, depth int) (frame, bool, error) {
+	ifv := reflect.ValueOf(iface)
+	if ifv.Kind() != reflect.Ptr {
+		return frame{}, false, fmt.Errorf("%w: %T", ErrNotPointer, iface)
+	}
+	ift := reflect.Indirect(ifv).Type()
+	if ift.Kind() != reflect.Struct {
+		return frame{}, false, nil
+	}
+	if w.opts.MaxDepth > 0 && depth > w.opts.MaxDepth {
+		return frame{}, false, fmt.Errorf("structs nested more than MaxDepth (%d) deep", w.opts.MaxDepth)
+	}
+	w, err := w.versioned(ift)
+	if err != nil {
+		return frame{}, false, err
+	}
+	return frame{w: w, depth: depth, ptr: iface, val: ifv.Elem(), order: w.fieldOrder(ift)}, true, nil
+}
+
+// next conforms the frame up to the next struct nested in it, returning that struct's frame, or the frame for
+// the structs in a slice or map. It returns false once the frame is done.
+func (f *frame) next() (frame, bool, error) {
+	if f.elems.IsValid() {
+		return f.nextElement()
+	}
+	if f.pushed {
+		f.pushed = false
+		f.field++
+	}
+	t := f.val.Type()
+	for ; f.field < len(f.order); f.field++ {
+		v := t.Field(f.order[f.field])
+		el := reflect.Indirect(f.val.FieldByName(v.Name))
+		child, ok, err := f.w.at(f.ptr, f.w.fieldPath(v.Name)).field(v, el, f.depth)
+		if err != nil {
+			return frame{}, false, err
+		}
+		if ok {
+			f.pushed = true
+			return child, true, nil
+		}
+	}
+	return frame{}, false, nil
+}
+
+// nextElement returns the frame for the next struct in a slice or map. Once a map value has been conformed, it's
+// set back in the map.
+func (f *frame) nextElement() (frame, bool, error) {
+	if f.scratch.IsValid() {
+		f.elems.SetMapIndex(f.keys[f.index-1], f.scratch.Elem())
+		f.scratch = reflect.Value{}
+	}
+
+	if f.elems.Kind() == reflect.Map {
+		for f.index < len(f.keys) {
+			key := f.keys[f.index]
+			f.index++
+			mapValue := f.elems.MapIndex(key)
+			ew := f.w.at(f.w.parent, f.w.elementPath(key))
+
+			// structs that values point to are conformed where they are
+			if mapValue.Kind() == reflect.Ptr {
+				if mapValue.IsNil() {
+					continue
+				}
+				child, ok, err := ew.structFrame(mapValue.Interface(), f.depth+1)
+				if ok || err != nil {
+					return child, ok, err
+				}
+				continue
+			}
+
+			// other values can't be changed in place, so are conformed in a copy that's set back in the map
+			scratch := reflect.New(mapValue.Type())
+			scratch.Elem().Set(mapValue)
+			child, ok, err := ew.structFrame(scratch.Interface(), f.depth+1)
+			if ok {
+				f.scratch = scratch
+				return child, true, nil
+			}
+			if err != nil {
+				return frame{}, false, err
+			}
+		}
+		return frame{}, false, nil
+	}
+
+	for f.index < f.elems.Len() {
+		i := f.index
+		f.index++
+		el := f.elems.Index(i)
+		if el.Kind() != reflect.Ptr {
+			el = el.Addr()
+		} else if el.IsNil() {
+			continue
+		}
+		child, ok, err := f.w.at(f.w.parent, f.w.elementPath(i)).structFrame(el.Interface(), f.depth+1)
+		if ok || err != nil {
+			return child, ok, err
+		}
+	}
+	return frame{}, false, nil
+}
+
+// field conforms the strings in a field. When it holds structs, it returns the frame for them instead.
+func (w walker) field(v reflect.StructField, el reflect.Value, depth int) (frame, bool, error) {
+	tags := w.tags(v)
+	switch el.Kind() {
+	case reflect.Slice:
+		if !el.CanInterface() {
+			break
+		}
+		elType := getSliceElemType(v.Type)
+
+		// bytes, such as json.RawMessage, aren't text to conform
+		if elType.Kind() == reflect.Uint8 {
+			break
+		}
+
+		// allow strings and string pointers
+		if isStringLike(elType) {
+			if len(tags) <= 0 {
+				break
+			}
+			for i := 0; i < el.Len(); i++ {
+				newVal, err := w.at(w.parent, w.elementPath(i)).transformValue(tags, el.Index(i))
+				if err != nil {
+					return frame{}, false, err
+				}
+				el.Index(i).Set(newVal)
+			}
+			break
+		}
+		val := reflect.ValueOf(el.Interface())
+		if w.parallel(val) {
+			return frame{}, false, w.conformElements(val, depth)
+		}
+		return frame{w: w, depth: depth, elems: val}, true, nil
+	case reflect.Map:
+		if !el.CanInterface() {
+			break
+		}
+		elType := getSliceElemType(v.Type)
+		val := reflect.ValueOf(el.Interface())
+
+		// allow strings and string pointers
+		if isStringLike(elType) {
+			if len(tags) <= 0 {
+				break
+			}
+			// done holds the pointers already conformed, so values sharing one aren't conformed twice
+			var done map[uintptr]bool
+			for _, key := range sortedKeys(val) {
+				mapValue := val.MapIndex(key)
+				ew := w.at(w.parent, w.elementPath(key))
+				if mapValue.Kind() != reflect.Ptr {
+					newVal, err := ew.transformValue(tags, mapValue)
+					if err != nil {
+						return frame{}, false, err
+					}
+					val.SetMapIndex(key, newVal)
+					continue
+				}
+				// set strings through their pointers, leaving the map as it is
+				if mapValue.IsNil() || done[mapValue.Pointer()] {
+					continue
+				}
+				if done == nil {
+					done = map[uintptr]bool{}
+				}
+				done[mapValue.Pointer()] = true
+				str, err := ew.transformString(mapValue.Elem().String(), tags)
+				if err != nil {
+					return frame{}, false, err
+				}
+				mapValue.Elem().SetString(str)
+			}
+			break
+		}
+		return frame{w: w, depth: depth, elems: val, keys: sortedKeys(val)}, true, nil
+	case reflect.Struct:
+		if el.CanAddr() && el.Addr().CanInterface() {
+			// To handle "sql.NullString" we can assume that tags are added to a field of type struct rather than string
+			if tags != "" && el.CanSet() {
+				field := el.FieldByName("String")
+				str, err := w.transformString(field.String(), tags)
+				if err != nil {
+					return frame{}, false, err
+				}
+				field.SetString(str)
+			} else {
+				return w.structFrame(el.Addr().Interface(), depth+1)
+			}
+		}
+	case reflect.String:
+		if el.CanSet() {
+			str, err := w.transformString(el.String(), tags)
+			if err != nil {
+				return frame{}, false, err
+			}
+			el.SetString(str)
+		}
+	}
+	return frame{}, false, nil
+}
+
+// parallel reports whether the elements of the slice of structs val can be conformed on their own goroutines.
+// Each goroutine walks its own stack, so elements whose structs hold pointers to other structs, which may be
+// shared between them or lead back to a struct being conformed, are conformed one after the other instead.
+func (w walker) parallel(val reflect.Value) bool {
+	if w.opts.Parallelism <= 1 || val.Len() <= 1 {
+		return false
+	}
+	el := val.Type().Elem()
+	if el.Kind() == reflect.Ptr {
+		el = el.Elem()
+	}
+	return !pointsToStruct(el)
+}
+
+// structPointers caches pointsToStruct, keyed by type
+var structPointers sync.Map
+
+// pointsToStruct reports whether a value of type t can hold a pointer to a struct, in its fields or in the slices
+// and maps it holds
+func pointsToStruct(t reflect.Type) bool {
+	if points, ok := structPointers.Load(t); ok {
+		return points.(bool)
+	}
+	points := reachesStructPointer(t, map[reflect.Type]bool{})
+	structPointers.Store(t, points)
+	return points
+}
+
+func reachesStructPointer(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Ptr:
+		return t.Elem().Kind() == reflect.Struct || reachesStructPointer(t.Elem(), seen)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return reachesStructPointer(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if reachesStructPointer(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// conformElements conforms the elements of a slice of structs on up to Parallelism goroutines, each walking its
+// own stack. Elements pointing to a struct an earlier one points to are skipped, so it's only conformed once. The
+// error for the first element that fails is returned.
+func (w walker) conformElements(val reflect.Value, depth int) error {
+	errs := make([]error, val.Len())
+	sem := make(chan struct{}, w.opts.Parallelism)
+	var wg sync.WaitGroup
+	var done map[uintptr]bool
+	for i := 0; i < val.Len(); i++ {
+		elVal := val.Index(i)
+		if elVal.Kind() != reflect.Ptr {
+			elVal = elVal.Addr()
+		} else if elVal.IsNil() || done[elVal.Pointer()] {
+			continue
+		} else {
+			if done == nil {
+				done = map[uintptr]bool{}
+			}
+			done[elVal.Pointer()] = true
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, elVal reflect.Value) {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = w.at(w.parent, w.elementPath(i)).conformStruct(elVal.Interface(), depth+1)
+		}(i, elVal)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
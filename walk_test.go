@@ -0,0 +1,167 @@
+package conform
+
+import (
+	"runtime/debug"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deepNode struct {
+	Name     string `conform:"trim"`
+	Next     *deepNode
+	Children map[string]deepNode
+}
+
+func (t *testSuite) TestDeepNesting() {
+	assert := assert.New(t.T())
+
+	root := &deepNode{}
+	n := root
+	for i := 0; i < 100000; i++ {
+		n.Name = " x "
+		n.Next = &deepNode{}
+		n = n.Next
+	}
+	n.Name = " last "
+	n.Children = map[string]deepNode{"a": {Name: " a "}}
+
+	// recursing this deep would need far more stack than this
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+	assert.NoError(Strings(root))
+	assert.Equal("x", root.Name)
+	assert.Equal("last", n.Name)
+	assert.Equal("a", n.Children["a"].Name)
+
+	err := StringsWithOptions(root, Options{MaxDepth: 2})
+	assert.EqualError(err, "Next: Next: Next: structs nested more than MaxDepth (2) deep")
+}
+
+func (t *testSuite) TestCycles() {
+	assert := assert.New(t.T())
+
+	type child struct {
+		Name   string `conform:"trim"`
+		Parent *deepNode
+	}
+	type parent struct {
+		Name     string `conform:"trim"`
+		Self     *parent
+		Children []child
+	}
+	p := &parent{Name: " p "}
+	p.Self = p
+	root := &deepNode{Name: " root "}
+	root.Next = root
+	p.Children = []child{{Name: " c ", Parent: root}}
+
+	assert.NoError(Strings(p), "Cycles should end without MaxDepth")
+	assert.Equal("p", p.Name)
+	assert.Equal("c", p.Children[0].Name)
+	assert.Equal("root", root.Name)
+	assert.Same(root, root.Next)
+
+	type ring struct {
+		Name string `conform:"trim"`
+		Next *ring
+	}
+	var hooked int
+	RegisterTypeHook(func(*ring) error {
+		hooked++
+		return nil
+	})
+	a, b := &ring{Name: " a "}, &ring{Name: " b "}
+	a.Next, b.Next = b, a
+	assert.NoError(Strings(a))
+	assert.Equal("b", b.Name)
+	assert.Equal(2, hooked, "Structs reached again through a cycle should be conformed once")
+}
+
+func (t *testSuite) TestMapValues() {
+	assert := assert.New(t.T())
+
+	type item struct {
+		SKU string `conform:"trim,upper"`
+	}
+	shared := " shared "
+	ptr := &item{SKU: " ab "}
+	var s struct {
+		Strings  map[string]string  `conform:"trim"`
+		Pointers map[string]*string `conform:"trim"`
+		Items    map[string]item
+		ItemPtrs map[string]*item
+	}
+	s.Strings = map[string]string{"a": " a "}
+	s.Pointers = map[string]*string{"a": &shared, "nil": nil}
+	s.Items = map[string]item{"a": {SKU: " cd "}}
+	s.ItemPtrs = map[string]*item{"a": ptr, "nil": nil}
+
+	assert.NoError(Strings(&s))
+	assert.Equal("a", s.Strings["a"])
+	assert.Equal("shared", shared, "Strings should be set through their pointers")
+	assert.Same(&shared, s.Pointers["a"])
+	assert.Nil(s.Pointers["nil"])
+	assert.Equal("CD", s.Items["a"].SKU)
+	assert.Equal("AB", ptr.SKU, "Structs should be conformed through their pointers")
+	assert.Same(ptr, s.ItemPtrs["a"])
+
+	r := NewRegistry()
+	r.AddSanitizer("exclaim", func(s string) string { return s + "!" })
+	hi := "hi"
+	exclaimed := struct {
+		Values map[string]*string `conform:"exclaim"`
+	}{map[string]*string{"a": &hi, "b": &hi}}
+	assert.NoError(StringsWithOptions(&exclaimed, Options{Registry: r}))
+	assert.Equal("hi!", hi, "Values sharing a pointer should be conformed once")
+}
+
+func (t *testSuite) TestSliceAndMapRoots() {
+	assert := assert.New(t.T())
+
+	type item struct {
+		SKU string `conform:"trim,upper"`
+	}
+
+	items := []item{{SKU: " a "}, {SKU: " b "}}
+	assert.NoError(Strings(&items))
+	assert.Equal([]item{{SKU: "A"}, {SKU: "B"}}, items)
+
+	ptrs := []*item{{SKU: " c "}, nil}
+	assert.NoError(StringsWithOptions(&ptrs, Options{Parallelism: 2}))
+	assert.Equal("C", ptrs[0].SKU)
+
+	byID := map[string]item{"1": {SKU: " d "}}
+	assert.NoError(Strings(&byID))
+	assert.Equal("D", byID["1"].SKU)
+
+	ptrByID := map[string]*item{"1": {SKU: " e "}}
+	assert.NoError(Strings(&ptrByID))
+	assert.Equal("E", ptrByID["1"].SKU)
+
+	type node struct {
+		Next *node
+	}
+	nodes := []node{{Next: &node{Next: &node{}}}}
+	assert.EqualError(StringsWithOptions(&nodes, Options{MaxDepth: 1}),
+		"Next: Next: structs nested more than MaxDepth (1) deep",
+		"Elements should be as deep as a struct passed on its own")
+}
+
+func (t *testSuite) TestStrictRoot() {
+	assert := assert.New(t.T())
+
+	n := 1
+	s := " x "
+	strs := []string{" y "}
+	assert.NoError(Strings(&n), "Unsupported roots should be left alone by default")
+
+	for _, v := range []interface{}{&n, &s, &strs} {
+		err := StringsWithOptions(v, Options{StrictRoot: true})
+		assert.ErrorIs(err, ErrUnsupportedRoot)
+	}
+	u := &struct{ Name string }{}
+	err := StringsWithOptions(&u, Options{StrictRoot: true})
+	assert.EqualError(err, "unsupported root: **struct { Name string }")
+
+	assert.NoError(StringsWithOptions(u, Options{StrictRoot: true}))
+	assert.NoError(StringsWithOptions(&[]struct{ Name string }{}, Options{StrictRoot: true}))
+}